@@ -9,6 +9,8 @@ import (
 
 	"github.com/markbmullins/city-developer/pkg/components"
 	"github.com/markbmullins/city-developer/pkg/ecs"
+	"github.com/markbmullins/city-developer/pkg/entities"
+	"github.com/markbmullins/city-developer/pkg/systems"
 	"github.com/markbmullins/city-developer/pkg/utils"
 )
 
@@ -18,8 +20,16 @@ type ControlTimePayload struct {
 }
 
 type BuyPropertyPayload struct {
-	PropertyID int `json:"property_id"`
-	PlayerID   int `json:"player_id"`
+	PropertyID  int            `json:"property_id"`
+	PlayerID    int            `json:"player_id"`
+	DownPayment float64        `json:"down_payment,omitempty"` // Financed purchases only
+	TermMonths  int            `json:"term_months,omitempty"`  // Omit to pay the full price in cash
+	Partners    []PartnerShare `json:"partners,omitempty"`     // Players offered a share once the player has bought; cash purchases only
+}
+
+type PartnerShare struct {
+	PlayerID   int     `json:"player_id"`
+	Percentage float64 `json:"percentage"`
 }
 
 type UpgradePropertyPayload struct {
@@ -31,6 +41,17 @@ type SellPropertyPayload struct {
 	PropertyID int `json:"property_id"`
 }
 
+type RepairPropertyPayload struct {
+	PropertyID int     `json:"property_id"`
+	Amount     float64 `json:"amount,omitempty"` // Omit to pay the full maintenance due
+}
+
+type SetRentPayload struct {
+	PropertyID int     `json:"property_id"`
+	Unit       int     `json:"unit"` // Unit number; 0 sets the rent of every unit
+	Rent       float64 `json:"rent"` // Monthly rent per unit; 0 returns the units to their standard rent
+}
+
 type ActionRequest struct {
 	Action  string      `json:"action"`
 	Payload interface{} `json:"payload"`
@@ -67,6 +88,90 @@ func HandleAction(world *ecs.World, w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		handleSellProperty(world, payload, w)
+	case "repair_property":
+		var payload RepairPropertyPayload
+		if !decodePayload(actionReq.Payload, &payload, w) {
+			return
+		}
+		handleRepairProperty(world, payload, w)
+	case "set_rent":
+		var payload SetRentPayload
+		if !decodePayload(actionReq.Payload, &payload, w) {
+			return
+		}
+		handleSetRent(world, payload, w)
+	case "screen_application":
+		var payload ApplicationPayload
+		if !decodePayload(actionReq.Payload, &payload, w) {
+			return
+		}
+		handleScreenApplication(world, payload, w)
+	case "accept_application":
+		var payload ApplicationPayload
+		if !decodePayload(actionReq.Payload, &payload, w) {
+			return
+		}
+		handleAcceptApplication(world, payload, w)
+	case "reject_application":
+		var payload ApplicationPayload
+		if !decodePayload(actionReq.Payload, &payload, w) {
+			return
+		}
+		handleRejectApplication(world, payload, w)
+	case "place_bid":
+		var payload PlaceBidPayload
+		if !decodePayload(actionReq.Payload, &payload, w) {
+			return
+		}
+		handlePlaceBid(world, payload, w)
+	case "offer_shares":
+		var payload OfferSharesPayload
+		if !decodePayload(actionReq.Payload, &payload, w) {
+			return
+		}
+		handleOfferShares(world, payload, w)
+	case "buy_shares":
+		var payload BuySharesPayload
+		if !decodePayload(actionReq.Payload, &payload, w) {
+			return
+		}
+		handleBuyShares(world, payload, w)
+	case "schedule_payment":
+		var payload SchedulePaymentPayload
+		if !decodePayload(actionReq.Payload, &payload, w) {
+			return
+		}
+		handleSchedulePayment(world, payload, w)
+	case "cancel_payment":
+		var payload CancelPaymentPayload
+		if !decodePayload(actionReq.Payload, &payload, w) {
+			return
+		}
+		handleCancelPayment(world, payload, w)
+	case "deposit_savings":
+		var payload BankTransactionPayload
+		if !decodePayload(actionReq.Payload, &payload, w) {
+			return
+		}
+		handleDepositSavings(world, payload, w)
+	case "withdraw_savings":
+		var payload BankTransactionPayload
+		if !decodePayload(actionReq.Payload, &payload, w) {
+			return
+		}
+		handleWithdrawSavings(world, payload, w)
+	case "draw_credit_line":
+		var payload BankTransactionPayload
+		if !decodePayload(actionReq.Payload, &payload, w) {
+			return
+		}
+		handleDrawCreditLine(world, payload, w)
+	case "repay_credit_line":
+		var payload BankTransactionPayload
+		if !decodePayload(actionReq.Payload, &payload, w) {
+			return
+		}
+		handleRepayCreditLine(world, payload, w)
 	case "control_time":
 		var payload ControlTimePayload
 		if !decodePayload(actionReq.Payload, &payload, w) {
@@ -130,7 +235,7 @@ func handleBuyProperty(world *ecs.World, data BuyPropertyPayload, w http.Respons
 	propertyFound := propertyEntity != nil
 	gameTime, _ := world.GetCurrentGameTime()
 
-	if !playerFound || !propertyFound {
+	if !playerFound || !propertyFound || playerEntity.Type != "Player" {
 		utils.SendResponse(w, http.StatusBadRequest, "Player or Property not found", nil)
 		return
 	}
@@ -138,19 +243,154 @@ func handleBuyProperty(world *ecs.World, data BuyPropertyPayload, w http.Respons
 	purchaseable, _ := propertyEntity.GetPurchaseable()
 	ownable, _ := propertyEntity.GetOwnable()
 
-	log.Printf("Player funds: %f, Property price: %f\n", funds.Amount, purchaseable.Cost)
-	if funds.Amount >= purchaseable.Cost {
-		funds.Amount -= purchaseable.Cost
-		ownable.Owned = true
-		ownable.OwnerID = playerID
-		purchaseable.PurchaseDate = gameTime.CurrentDate
+	if ownable.Owned {
+		utils.SendResponse(w, http.StatusBadRequest, "Property is already owned", nil)
+		return
+	}
 
-		// Append the property to the player's list of properties
-		world.BuyProperty(propertyID, playerID)
-		utils.SendResponse(w, http.StatusOK, "Property purchased successfully", world)
-	} else {
-		utils.SendResponse(w, http.StatusBadRequest, "Insufficient funds", nil)
+	if systems.IsUpForAuction(propertyEntity) {
+		utils.SendResponse(w, http.StatusBadRequest, "Property is up for auction", nil)
+		return
+	}
+
+	if len(data.Partners) > 0 {
+		handlePartnerPurchase(world, data, w)
+		return
+	}
+
+	if data.TermMonths > 0 {
+		handleFinancedPurchase(world, data, w)
+		return
+	}
+
+	// Properties are bought at their current market value
+	price := systems.PropertyValue(propertyEntity)
+
+	log.Printf("Player funds: %f, Property price: %f\n", funds.Amount, price)
+	if !checkFunds(playerEntity, price, w) {
+		return
+	}
+
+	playerEntity.PostTransaction(gameTime.CurrentDate, components.PropertyPurchase, -price, propertyID, "Property purchase")
+	ownable.Owned = true
+	ownable.OwnerID = playerID
+	purchaseable.Cost = price
+	purchaseable.PurchaseDate = gameTime.CurrentDate
+
+	// Append the property to the player's list of properties
+	world.BuyProperty(propertyID, playerID)
+	utils.SendResponse(w, http.StatusOK, "Property purchased successfully", world)
+}
+
+// handleFinancedPurchase buys a property with a down payment and finances the rest
+// with one of the standard loan offers. Players and property are assumed to exist.
+func handleFinancedPurchase(world *ecs.World, data BuyPropertyPayload, w http.ResponseWriter) {
+	playerEntity := world.GetEntity(data.PlayerID)
+	propertyEntity := world.GetEntity(data.PropertyID)
+	gameTime, _ := world.GetCurrentGameTime()
+
+	purchaseable, _ := propertyEntity.GetPurchaseable()
+	ownable, _ := propertyEntity.GetOwnable()
+
+	annualRate, offered := systems.LoanRate(world, data.TermMonths)
+	if !offered {
+		utils.SendResponse(w, http.StatusBadRequest, "No loan offered for the requested term", systems.CurrentLoanOffers(world))
+		return
+	}
+
+	price := systems.PropertyValue(propertyEntity)
+	minDownPayment := price * components.MinDownPaymentPercentage / 100
+	if data.DownPayment < minDownPayment || data.DownPayment > price {
+		utils.SendResponse(w, http.StatusBadRequest, fmt.Sprintf("Down payment must be between %.2f and %.2f", minDownPayment, price), nil)
+		return
+	}
+
+	if !checkFunds(playerEntity, data.DownPayment, w) {
+		return
+	}
+
+	// The mortgage is attached before any money moves so a property that can't take a loan isn't half bought
+	principal := price - data.DownPayment
+	if principal > 0 {
+		loan := entities.CreateLoan(data.PlayerID, principal, annualRate, data.TermMonths, gameTime.CurrentDate)
+		if err := world.AddLoanToProperty(propertyEntity, loan); err != nil {
+			log.Printf("Failed to attach loan to property %d: %v\n", data.PropertyID, err)
+			utils.SendResponse(w, http.StatusBadRequest, "Property already has a loan against it", nil)
+			return
+		}
+		world.AddEntity(entities.CreateMortgagePayment(data.PropertyID, loan))
+	}
+
+	playerEntity.PostTransaction(gameTime.CurrentDate, components.PropertyPurchase, -price, data.PropertyID, "Property purchase")
+	if principal > 0 {
+		playerEntity.PostTransaction(gameTime.CurrentDate, components.LoanProceeds, principal, data.PropertyID, "Mortgage proceeds")
 	}
+	ownable.Owned = true
+	ownable.OwnerID = data.PlayerID
+	purchaseable.Cost = price
+	purchaseable.PurchaseDate = gameTime.CurrentDate
+	world.BuyProperty(data.PropertyID, data.PlayerID)
+
+	utils.SendResponse(w, http.StatusOK, "Property purchased successfully", world)
+}
+
+// handlePartnerPurchase buys a property outright in cash and offers each partner their share at cost.
+// Partners join only if they accept the offer with buy_shares. Players and property are assumed to exist.
+func handlePartnerPurchase(world *ecs.World, data BuyPropertyPayload, w http.ResponseWriter) {
+	playerEntity := world.GetEntity(data.PlayerID)
+	propertyEntity := world.GetEntity(data.PropertyID)
+	gameTime, _ := world.GetCurrentGameTime()
+
+	if data.TermMonths > 0 {
+		utils.SendResponse(w, http.StatusBadRequest, "Properties bought with partners must be paid in cash", nil)
+		return
+	}
+
+	partnerShares := map[int]float64{}
+	partnersTotal := 0.0
+	for _, partner := range data.Partners {
+		partnerEntity := world.GetEntity(partner.PlayerID)
+		if partnerEntity == nil || partnerEntity.Type != "Player" || partner.PlayerID == data.PlayerID {
+			utils.SendResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid partner %d", partner.PlayerID), nil)
+			return
+		}
+		if partner.Percentage <= 0 {
+			utils.SendResponse(w, http.StatusBadRequest, "Partner percentages must be positive", nil)
+			return
+		}
+		partnerShares[partner.PlayerID] += partner.Percentage
+		partnersTotal += partner.Percentage
+	}
+	if partnersTotal >= 100 {
+		utils.SendResponse(w, http.StatusBadRequest, "Partners must leave a share for the buyer", nil)
+		return
+	}
+
+	// The buyer pays the full price until the partners buy in
+	price := systems.PropertyValue(propertyEntity)
+	if !checkFunds(playerEntity, price, w) {
+		return
+	}
+	playerEntity.PostTransaction(gameTime.CurrentDate, components.PropertyPurchase, -price, data.PropertyID, "Property purchase")
+
+	purchaseable, _ := propertyEntity.GetPurchaseable()
+	ownable, _ := propertyEntity.GetOwnable()
+	ownable.Owned = true
+	ownable.OwnerID = data.PlayerID
+	purchaseable.Cost = price
+	purchaseable.PurchaseDate = gameTime.CurrentDate
+	world.BuyProperty(data.PropertyID, data.PlayerID)
+
+	for _, partnerID := range systems.SortedShareholderIDs(partnerShares) {
+		ownable.ShareOffers = append(ownable.ShareOffers, components.ShareOffer{
+			SellerID:   data.PlayerID,
+			BuyerID:    partnerID,
+			Percentage: partnerShares[partnerID],
+			Price:      price * partnerShares[partnerID] / 100,
+		})
+	}
+
+	utils.SendResponse(w, http.StatusOK, "Property purchased and shares offered to partners", ownable)
 }
 
 func handleUpgradeProperty(world *ecs.World, data UpgradePropertyPayload, w http.ResponseWriter) {
@@ -178,31 +418,35 @@ func handleUpgradeProperty(world *ecs.World, data UpgradePropertyPayload, w http
 	}
 
 	upgradePath, exists := upgradable.PossibleUpgrades[upgradePathName]
-	if !exists || len(upgradePath) <= len(upgradable.AppliedUpgrades) {
-		utils.SendResponse(w, http.StatusBadRequest, "Invalid upgrade path or max level reached", nil)
+	if !exists {
+		utils.SendResponse(w, http.StatusBadRequest, "Invalid upgrade path", nil)
 		return
 	}
 
-	currentLevel := len(upgradePath)
+	currentLevel := upgradable.CurrentUpgradeLevel(upgradePathName)
 
 	// Check if the current level is below the maximum for the upgrade path
-	if currentLevel >= len(upgradePath)-1 {
+	if currentLevel >= len(upgradePath) {
 		utils.SendResponse(w, http.StatusBadRequest, "Max upgrade level reached in this path", nil)
 		return
 	}
 
 	// Retrieve the next upgrade details
-	nextUpgrade := upgradePath[currentLevel+1]
+	nextUpgrade := upgradePath[currentLevel]
 
-	playerEntity := world.GetEntity(ownable.OwnerID)
-	playerFunds, _ := playerEntity.GetFunds()
+	// Upgrade costs rise with inflation
+	cost := nextUpgrade.Cost * systems.InflationIndex(world)
 
-	// Deduct the upgrade cost
-	playerFunds.Amount -= nextUpgrade.Cost
+	if !checkShareholderFunds(world, propertyEntity, cost, w) {
+		return
+	}
 
 	// Get current game time
 	gameTime, _ := world.GetCurrentGameTime()
 
+	// Deduct the upgrade cost, split between the shareholders
+	systems.PostPropertyTransaction(world, propertyEntity, gameTime.CurrentDate, components.UpgradeSpend, -cost, nextUpgrade.Name)
+
 	// Set the PurchaseDate to current game time
 	purchaseDate := gameTime.CurrentDate
 
@@ -210,7 +454,7 @@ func handleUpgradeProperty(world *ecs.World, data UpgradePropertyPayload, w http
 	newUpgrade := components.Upgrade{
 		Name:           nextUpgrade.Name,
 		Level:          currentLevel + 1,
-		Cost:           nextUpgrade.Cost,
+		Cost:           cost,
 		RentIncrease:   nextUpgrade.RentIncrease,
 		DaysToComplete: nextUpgrade.DaysToComplete,
 		PurchaseDate:   purchaseDate,
@@ -258,7 +502,7 @@ func handleSellProperty(world *ecs.World, data SellPropertyPayload, w http.Respo
 	}
 
 	var ownable, err = propertyEntity.GetOwnable()
-	if err != nil {
+	if err != nil || !ownable.Owned {
 		utils.SendResponse(w, http.StatusBadRequest, "Property is not owned", nil)
 		return
 	}
@@ -268,17 +512,160 @@ func handleSellProperty(world *ecs.World, data SellPropertyPayload, w http.Respo
 		utils.SendResponse(w, http.StatusBadRequest, "Owner not found", nil)
 		return
 	}
+	if !checkNotBankrupt(ownerEntity, w) {
+		return
+	}
+	if systems.IsInForeclosure(propertyEntity) {
+		utils.SendResponse(w, http.StatusBadRequest, "Property is being foreclosed", nil)
+		return
+	}
+
+	breakdown := systems.SellProperty(world, propertyEntity)
+	utils.SendResponse(w, http.StatusOK, "Property sold successfully", breakdown)
+}
+
+func handleRepairProperty(world *ecs.World, data RepairPropertyPayload, w http.ResponseWriter) {
+	propertyEntity := world.GetEntity(data.PropertyID)
+	if propertyEntity == nil {
+		utils.SendResponse(w, http.StatusNotFound, "Property not found", nil)
+		return
+	}
+
+	ownable, _ := propertyEntity.GetOwnable()
+	if ownable == nil || !ownable.Owned {
+		utils.SendResponse(w, http.StatusBadRequest, "Property is not owned", nil)
+		return
+	}
+
+	maintainable, err := propertyEntity.GetMaintainable()
+	if err != nil || maintainable.MaintenanceDue <= 0 {
+		utils.SendResponse(w, http.StatusBadRequest, "Property has no maintenance due", nil)
+		return
+	}
 
-	var purchaseable, _ = propertyEntity.GetPurchaseable()
-	salePrice := purchaseable.Cost * 0.8
-	var fundsComponent, _ = ownerEntity.GetFunds()
-	fundsComponent.Amount += salePrice
+	if data.Amount < 0 {
+		utils.SendResponse(w, http.StatusBadRequest, "Invalid repair amount", nil)
+		return
+	}
+	amount := data.Amount
+	if amount == 0 || amount > maintainable.MaintenanceDue {
+		amount = maintainable.MaintenanceDue
+	}
+
+	if !checkShareholderFunds(world, propertyEntity, amount, w) {
+		return
+	}
 
-	// Remove the property from the player's Properties array
-	world.SellProperty(propertyID)
-	ownable.Owned = false
-	ownable.OwnerID = 0
-	utils.SendResponse(w, http.StatusOK, "Property sold successfully", world)
+	gameTime, _ := world.GetCurrentGameTime()
+	systems.PostPropertyTransaction(world, propertyEntity, gameTime.CurrentDate, components.MaintenanceSpend, -amount, "Property repairs")
+
+	// Condition is restored in proportion to the share of the maintenance due that was paid
+	share := amount / maintainable.MaintenanceDue
+	maintainable.MaintenanceDue -= amount
+	maintainable.Condition += (100 - maintainable.Condition) * share
+	if maintainable.MaintenanceDue <= 0.005 {
+		maintainable.MaintenanceDue = 0
+		maintainable.Condition = 100
+		maintainable.DamageEvents = []string{}
+	}
+
+	utils.SendResponse(w, http.StatusOK, "Property repaired successfully", maintainable)
+}
+
+func handleSetRent(world *ecs.World, data SetRentPayload, w http.ResponseWriter) {
+	propertyEntity := world.GetEntity(data.PropertyID)
+	if propertyEntity == nil {
+		utils.SendResponse(w, http.StatusNotFound, "Property not found", nil)
+		return
+	}
+
+	ownable, _ := propertyEntity.GetOwnable()
+	if ownable == nil || !ownable.Owned {
+		utils.SendResponse(w, http.StatusBadRequest, "Property is not owned", nil)
+		return
+	}
+	if !checkNotBankrupt(world.GetEntity(ownable.OwnerID), w) {
+		return
+	}
+
+	rentable, err := propertyEntity.GetRentable()
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest, "Property is not rentable", nil)
+		return
+	}
+	if data.Rent < 0 {
+		utils.SendResponse(w, http.StatusBadRequest, "Invalid rent", nil)
+		return
+	}
+	units := rentable.Units
+	if data.Unit != 0 {
+		unit := rentable.Unit(data.Unit)
+		if unit == nil {
+			utils.SendResponse(w, http.StatusNotFound, "Unit not found", nil)
+			return
+		}
+		units = []*components.Unit{unit}
+	}
+
+	gameTime, _ := world.GetCurrentGameTime()
+	unitData := []map[string]interface{}{}
+	for _, unit := range units {
+		unit.AskingRent = data.Rent
+		unitResponse := map[string]interface{}{
+			"unit":         unit.Number,
+			"asking_rent":  unit.AskingRent,
+			"desired_rent": systems.UnitDesiredRent(world, propertyEntity, unit, gameTime.CurrentDate),
+		}
+		if unit.AskingRent > 0 {
+			unitResponse["occupancy_probability"] = systems.OccupancyProbability(world, propertyEntity, unit, unit.AskingRent, gameTime.CurrentDate)
+		}
+		unitData = append(unitData, unitResponse)
+	}
+
+	responseData := map[string]interface{}{
+		"property_id": data.PropertyID,
+		"units":       unitData,
+	}
+	utils.SendResponse(w, http.StatusOK, "Rent set successfully", responseData)
+}
+
+// checkNotBankrupt responds with an error and returns false if the player has gone bankrupt.
+func checkNotBankrupt(playerEntity *ecs.Entity, w http.ResponseWriter) bool {
+	if solvency, err := playerEntity.GetSolvency(); err == nil && solvency.Status == components.Bankrupt {
+		utils.SendResponse(w, http.StatusBadRequest, "Player is bankrupt", nil)
+		return false
+	}
+	return true
+}
+
+// checkFunds responds with an error and returns false if the player cannot spend the amount.
+// Every action that spends a player's funds must check it first so balances never go negative.
+func checkFunds(playerEntity *ecs.Entity, amount float64, w http.ResponseWriter) bool {
+	if !checkNotBankrupt(playerEntity, w) {
+		return false
+	}
+	funds, err := playerEntity.GetFunds()
+	if err != nil || funds.Amount < amount {
+		utils.SendResponse(w, http.StatusBadRequest, "Insufficient funds", nil)
+		return false
+	}
+	return true
+}
+
+// checkShareholderFunds responds with an error and returns false if any owner of the property
+// cannot pay their share of the amount.
+func checkShareholderFunds(world *ecs.World, propertyEntity *ecs.Entity, amount float64, w http.ResponseWriter) bool {
+	ownable, _ := propertyEntity.GetOwnable()
+	for playerID := range ownable.Shareholders() {
+		if !checkNotBankrupt(world.GetEntity(playerID), w) {
+			return false
+		}
+	}
+	if canAfford, player := systems.CanShareholdersAfford(world, propertyEntity, amount); !canAfford {
+		utils.SendResponse(w, http.StatusBadRequest, fmt.Sprintf("Insufficient funds for player %d", player.ID), nil)
+		return false
+	}
+	return true
 }
 
 func decodePayload(input interface{}, target interface{}, w http.ResponseWriter) bool {
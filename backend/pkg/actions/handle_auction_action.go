@@ -0,0 +1,59 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+	"github.com/markbmullins/city-developer/pkg/utils"
+)
+
+type PlaceBidPayload struct {
+	PropertyID int     `json:"property_id"`
+	PlayerID   int     `json:"player_id"`
+	Amount     float64 `json:"amount"`
+}
+
+func handlePlaceBid(world *ecs.World, data PlaceBidPayload, w http.ResponseWriter) {
+	playerEntity := world.GetEntity(data.PlayerID)
+	if playerEntity == nil || playerEntity.Type != "Player" {
+		utils.SendResponse(w, http.StatusNotFound, "Player not found", nil)
+		return
+	}
+
+	propertyEntity := world.GetEntity(data.PropertyID)
+	if propertyEntity == nil {
+		utils.SendResponse(w, http.StatusNotFound, "Property not found", nil)
+		return
+	}
+
+	auction, err := propertyEntity.GetAuction()
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest, "Property is not up for auction", nil)
+		return
+	}
+
+	gameTime, _ := world.GetCurrentGameTime()
+	if gameTime.CurrentDate.Before(auction.StartDate) || !gameTime.CurrentDate.Before(auction.EndDate) {
+		utils.SendResponse(w, http.StatusBadRequest, "Bidding is not open", auction)
+		return
+	}
+
+	if minimumBid := auction.MinimumBid(); data.Amount < minimumBid {
+		utils.SendResponse(w, http.StatusBadRequest, fmt.Sprintf("Bid must be at least %.2f", minimumBid), nil)
+		return
+	}
+
+	if !checkFunds(playerEntity, data.Amount, w) {
+		return
+	}
+
+	auction.Bids = append(auction.Bids, components.Bid{
+		BidderID: data.PlayerID,
+		Amount:   data.Amount,
+		Date:     gameTime.CurrentDate,
+	})
+
+	utils.SendResponse(w, http.StatusOK, "Bid placed successfully", auction)
+}
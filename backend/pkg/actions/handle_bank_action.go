@@ -0,0 +1,118 @@
+package actions
+
+import (
+	"net/http"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+	"github.com/markbmullins/city-developer/pkg/systems"
+	"github.com/markbmullins/city-developer/pkg/utils"
+)
+
+type BankTransactionPayload struct {
+	PlayerID int     `json:"player_id"`
+	Amount   float64 `json:"amount"`
+}
+
+// getBankAccount looks up the player and their bank account, responding with an error if either is missing,
+// the player is bankrupt or the amount is not positive.
+func getBankAccount(world *ecs.World, data BankTransactionPayload, w http.ResponseWriter) (*ecs.Entity, *components.BankAccount, bool) {
+	playerEntity := world.GetEntity(data.PlayerID)
+	if playerEntity == nil || playerEntity.Type != "Player" {
+		utils.SendResponse(w, http.StatusNotFound, "Player not found", nil)
+		return nil, nil, false
+	}
+
+	if !checkNotBankrupt(playerEntity, w) {
+		return nil, nil, false
+	}
+
+	account, err := playerEntity.GetBankAccount()
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest, "Player has no bank account", nil)
+		return nil, nil, false
+	}
+
+	if data.Amount <= 0 {
+		utils.SendResponse(w, http.StatusBadRequest, "Amount must be positive", nil)
+		return nil, nil, false
+	}
+
+	return playerEntity, account, true
+}
+
+func handleDepositSavings(world *ecs.World, data BankTransactionPayload, w http.ResponseWriter) {
+	playerEntity, account, ok := getBankAccount(world, data, w)
+	if !ok {
+		return
+	}
+
+	if !checkFunds(playerEntity, data.Amount, w) {
+		return
+	}
+
+	gameTime, _ := world.GetCurrentGameTime()
+	playerEntity.PostTransaction(gameTime.CurrentDate, components.SavingsDeposit, -data.Amount, 0, "Savings deposit")
+	account.SavingsBalance += data.Amount
+
+	utils.SendResponse(w, http.StatusOK, "Deposit made successfully", account)
+}
+
+func handleWithdrawSavings(world *ecs.World, data BankTransactionPayload, w http.ResponseWriter) {
+	playerEntity, account, ok := getBankAccount(world, data, w)
+	if !ok {
+		return
+	}
+
+	if account.SavingsBalance < data.Amount {
+		utils.SendResponse(w, http.StatusBadRequest, "Insufficient savings", nil)
+		return
+	}
+
+	gameTime, _ := world.GetCurrentGameTime()
+	account.SavingsBalance -= data.Amount
+	playerEntity.PostTransaction(gameTime.CurrentDate, components.SavingsWithdrawal, data.Amount, 0, "Savings withdrawal")
+
+	utils.SendResponse(w, http.StatusOK, "Withdrawal made successfully", account)
+}
+
+func handleDrawCreditLine(world *ecs.World, data BankTransactionPayload, w http.ResponseWriter) {
+	playerEntity, account, ok := getBankAccount(world, data, w)
+	if !ok {
+		return
+	}
+
+	account.CreditLimit = systems.CreditLimit(world, playerEntity)
+	if account.CreditLineBalance+data.Amount > account.CreditLimit {
+		utils.SendResponse(w, http.StatusBadRequest, "Credit limit exceeded", account)
+		return
+	}
+
+	gameTime, _ := world.GetCurrentGameTime()
+	account.CreditLineBalance += data.Amount
+	playerEntity.PostTransaction(gameTime.CurrentDate, components.CreditLineDraw, data.Amount, 0, "Credit line draw")
+
+	utils.SendResponse(w, http.StatusOK, "Credit line drawn successfully", account)
+}
+
+func handleRepayCreditLine(world *ecs.World, data BankTransactionPayload, w http.ResponseWriter) {
+	playerEntity, account, ok := getBankAccount(world, data, w)
+	if !ok {
+		return
+	}
+
+	amount := data.Amount
+	if amount > account.CreditLineBalance {
+		amount = account.CreditLineBalance
+	}
+
+	if !checkFunds(playerEntity, amount, w) {
+		return
+	}
+
+	gameTime, _ := world.GetCurrentGameTime()
+	playerEntity.PostTransaction(gameTime.CurrentDate, components.CreditLineRepayment, -amount, 0, "Credit line repayment")
+	account.CreditLineBalance -= amount
+
+	utils.SendResponse(w, http.StatusOK, "Credit line repaid successfully", account)
+}
@@ -0,0 +1,133 @@
+package actions
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+	"github.com/markbmullins/city-developer/pkg/entities"
+	"github.com/markbmullins/city-developer/pkg/utils"
+)
+
+type SchedulePaymentPayload struct {
+	PlayerID      int     `json:"player_id"`
+	PayeeID       int     `json:"payee_id"`
+	Amount        float64 `json:"amount"`
+	Frequency     string  `json:"frequency,omitempty"`      // Monthly (default), Quarterly or Yearly
+	StartDate     string  `json:"start_date,omitempty"`     // YYYY-MM-DD; defaults to the first of next month
+	EndDate       string  `json:"end_date,omitempty"`       // YYYY-MM-DD; omit for no end
+	FailurePolicy string  `json:"failure_policy,omitempty"` // Skip (default), Accrue or Cancel
+	Description   string  `json:"description,omitempty"`
+}
+
+type CancelPaymentPayload struct {
+	PlayerID  int `json:"player_id"`
+	PaymentID int `json:"payment_id"`
+}
+
+// handleSchedulePayment sets up a standing transfer from one player to another.
+func handleSchedulePayment(world *ecs.World, data SchedulePaymentPayload, w http.ResponseWriter) {
+	playerEntity := world.GetEntity(data.PlayerID)
+	if playerEntity == nil || playerEntity.Type != "Player" {
+		utils.SendResponse(w, http.StatusNotFound, "Player not found", nil)
+		return
+	}
+	if !checkNotBankrupt(playerEntity, w) {
+		return
+	}
+	payeeEntity := world.GetEntity(data.PayeeID)
+	if payeeEntity == nil || payeeEntity.Type != "Player" || data.PayeeID == data.PlayerID {
+		utils.SendResponse(w, http.StatusBadRequest, "Invalid payee", nil)
+		return
+	}
+	if data.Amount <= 0 {
+		utils.SendResponse(w, http.StatusBadRequest, "Amount must be positive", nil)
+		return
+	}
+
+	frequency := components.PaymentFrequency(data.Frequency)
+	if frequency == "" {
+		frequency = components.MonthlyFrequency
+	}
+	if _, ok := components.PaymentFrequencyMonths[frequency]; !ok {
+		utils.SendResponse(w, http.StatusBadRequest, "Invalid frequency", nil)
+		return
+	}
+
+	failurePolicy := components.PaymentFailurePolicy(data.FailurePolicy)
+	if failurePolicy == "" {
+		failurePolicy = components.SkipFailedPayment
+	}
+	if failurePolicy != components.SkipFailedPayment && failurePolicy != components.AccrueFailedPayment && failurePolicy != components.CancelOnFailure {
+		utils.SendResponse(w, http.StatusBadRequest, "Invalid failure_policy", nil)
+		return
+	}
+
+	gameTime, _ := world.GetCurrentGameTime()
+	today := gameTime.CurrentDate
+	startDate := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location()).AddDate(0, 1, 0)
+	if data.StartDate != "" {
+		parsed, err := time.Parse(time.DateOnly, data.StartDate)
+		if err != nil || parsed.Before(today) {
+			utils.SendResponse(w, http.StatusBadRequest, "Invalid start_date", nil)
+			return
+		}
+		startDate = parsed
+	}
+	var endDate time.Time
+	if data.EndDate != "" {
+		parsed, err := time.Parse(time.DateOnly, data.EndDate)
+		if err != nil || parsed.Before(startDate) {
+			utils.SendResponse(w, http.StatusBadRequest, "Invalid end_date", nil)
+			return
+		}
+		endDate = parsed
+	}
+
+	description := data.Description
+	if description == "" {
+		description = "Standing payment"
+	}
+
+	paymentEntity := entities.CreateRecurringPayment(
+		description,
+		data.PlayerID,
+		data.PayeeID,
+		0,
+		components.PlayerTransfer,
+		data.Amount,
+		frequency,
+		startDate,
+		endDate,
+		failurePolicy,
+	)
+	world.AddEntity(paymentEntity)
+
+	utils.SendResponse(w, http.StatusOK, "Payment scheduled successfully", paymentEntity)
+}
+
+// handleCancelPayment cancels a standing transfer set up by the player.
+func handleCancelPayment(world *ecs.World, data CancelPaymentPayload, w http.ResponseWriter) {
+	paymentEntity := world.GetEntity(data.PaymentID)
+	if paymentEntity == nil {
+		utils.SendResponse(w, http.StatusNotFound, "Payment not found", nil)
+		return
+	}
+	payment, err := paymentEntity.GetRecurringPayment()
+	if err != nil {
+		utils.SendResponse(w, http.StatusNotFound, "Payment not found", nil)
+		return
+	}
+	if payment.PayerID != data.PlayerID {
+		utils.SendResponse(w, http.StatusBadRequest, "Only the payer can cancel a payment", nil)
+		return
+	}
+	if payment.Mortgage {
+		utils.SendResponse(w, http.StatusBadRequest, "Mortgage payments end when the loan is paid off or the property is sold", nil)
+		return
+	}
+
+	world.RemoveEntity(paymentEntity.ID)
+	utils.SendResponse(w, http.StatusOK, "Payment cancelled successfully", payment)
+}
@@ -0,0 +1,157 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+	"github.com/markbmullins/city-developer/pkg/systems"
+	"github.com/markbmullins/city-developer/pkg/utils"
+)
+
+type OfferSharesPayload struct {
+	PropertyID int     `json:"property_id"`
+	PlayerID   int     `json:"player_id"`
+	BuyerID    int     `json:"buyer_id,omitempty"` // Reserves the offer for one player; omit to offer to anyone
+	Percentage float64 `json:"percentage"`         // 0 withdraws the player's offer
+	Price      float64 `json:"price"`
+}
+
+type BuySharesPayload struct {
+	PropertyID int `json:"property_id"`
+	PlayerID   int `json:"player_id"`
+	SellerID   int `json:"seller_id"`
+}
+
+// handleOfferShares lists part of a player's share of a property for sale, replacing any offer they already have on it
+// to the same buyer.
+func handleOfferShares(world *ecs.World, data OfferSharesPayload, w http.ResponseWriter) {
+	propertyEntity := world.GetEntity(data.PropertyID)
+	if propertyEntity == nil {
+		utils.SendResponse(w, http.StatusNotFound, "Property not found", nil)
+		return
+	}
+	ownable, err := propertyEntity.GetOwnable()
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest, "Property cannot be owned", nil)
+		return
+	}
+	if systems.IsInForeclosure(propertyEntity) {
+		utils.SendResponse(w, http.StatusBadRequest, "Property is being foreclosed", nil)
+		return
+	}
+
+	share := ownable.Share(data.PlayerID)
+	if share <= 0 {
+		utils.SendResponse(w, http.StatusBadRequest, "Player does not own a share of this property", nil)
+		return
+	}
+	if ownable.ForcedOffer(data.PlayerID) != nil {
+		utils.SendResponse(w, http.StatusBadRequest, "Shares are being sold to cover negative funds", nil)
+		return
+	}
+	if data.Percentage < 0 || data.Percentage > share {
+		utils.SendResponse(w, http.StatusBadRequest, fmt.Sprintf("Percentage must be between 0 and %.2f", share), nil)
+		return
+	}
+	if data.Percentage > 0 && data.Price <= 0 {
+		utils.SendResponse(w, http.StatusBadRequest, "Price must be positive", nil)
+		return
+	}
+	if data.BuyerID != 0 {
+		if buyer := world.GetEntity(data.BuyerID); buyer == nil || buyer.Type != "Player" || data.BuyerID == data.PlayerID {
+			utils.SendResponse(w, http.StatusBadRequest, fmt.Sprintf("Invalid buyer %d", data.BuyerID), nil)
+			return
+		}
+	}
+
+	offers := []components.ShareOffer{}
+	for _, offer := range ownable.ShareOffers {
+		if offer.SellerID != data.PlayerID || offer.BuyerID != data.BuyerID {
+			offers = append(offers, offer)
+		}
+	}
+	if data.Percentage == 0 {
+		ownable.ShareOffers = offers
+		utils.SendResponse(w, http.StatusOK, "Share offer withdrawn", ownable)
+		return
+	}
+
+	ownable.ShareOffers = append(offers, components.ShareOffer{
+		SellerID:   data.PlayerID,
+		BuyerID:    data.BuyerID,
+		Percentage: data.Percentage,
+		Price:      data.Price,
+	})
+	utils.SendResponse(w, http.StatusOK, "Shares offered successfully", ownable)
+}
+
+// handleBuyShares buys the shares a seller has on offer for a property, taking an offer reserved for the player
+// before one open to anyone.
+func handleBuyShares(world *ecs.World, data BuySharesPayload, w http.ResponseWriter) {
+	playerEntity := world.GetEntity(data.PlayerID)
+	if playerEntity == nil || playerEntity.Type != "Player" {
+		utils.SendResponse(w, http.StatusNotFound, "Player not found", nil)
+		return
+	}
+	if data.PlayerID == data.SellerID {
+		utils.SendResponse(w, http.StatusBadRequest, "Players cannot buy their own shares", nil)
+		return
+	}
+
+	propertyEntity := world.GetEntity(data.PropertyID)
+	if propertyEntity == nil {
+		utils.SendResponse(w, http.StatusNotFound, "Property not found", nil)
+		return
+	}
+	ownable, err := propertyEntity.GetOwnable()
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest, "Property cannot be owned", nil)
+		return
+	}
+	if systems.IsInForeclosure(propertyEntity) {
+		utils.SendResponse(w, http.StatusBadRequest, "Property is being foreclosed", nil)
+		return
+	}
+
+	offerIndex := -1
+	for i, offer := range ownable.ShareOffers {
+		if offer.SellerID != data.SellerID {
+			continue
+		}
+		if offer.BuyerID == data.PlayerID {
+			offerIndex = i
+			break
+		}
+		if offer.BuyerID == 0 && offerIndex < 0 {
+			offerIndex = i
+		}
+	}
+	if offerIndex < 0 {
+		utils.SendResponse(w, http.StatusBadRequest, "Seller has no shares on offer for this property", nil)
+		return
+	}
+	offer := ownable.ShareOffers[offerIndex]
+
+	// The seller may have sold part of their share since making the offer
+	if offer.Percentage > ownable.Share(data.SellerID) {
+		utils.SendResponse(w, http.StatusBadRequest, "Seller no longer owns the shares on offer", nil)
+		return
+	}
+
+	if !checkFunds(playerEntity, offer.Price, w) {
+		return
+	}
+
+	gameTime, _ := world.GetCurrentGameTime()
+	playerEntity.PostTransaction(gameTime.CurrentDate, components.SharePurchase, -offer.Price, data.PropertyID, "Property share purchase")
+	if seller := world.GetEntity(data.SellerID); seller != nil {
+		seller.PostTransaction(gameTime.CurrentDate, components.ShareSale, offer.Price, data.PropertyID, "Property share sale")
+	}
+
+	ownable.ShareOffers = append(ownable.ShareOffers[:offerIndex], ownable.ShareOffers[offerIndex+1:]...)
+	systems.TransferShares(world, propertyEntity, data.SellerID, data.PlayerID, offer.Percentage)
+
+	utils.SendResponse(w, http.StatusOK, "Shares purchased successfully", ownable)
+}
@@ -0,0 +1,91 @@
+package actions
+
+import (
+	"net/http"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+	"github.com/markbmullins/city-developer/pkg/systems"
+	"github.com/markbmullins/city-developer/pkg/utils"
+)
+
+type ApplicationPayload struct {
+	PropertyID    int `json:"property_id"`
+	ApplicationID int `json:"application_id"`
+}
+
+// handleScreenApplication charges the owners the screening fee and reveals the applicant's credit score.
+func handleScreenApplication(world *ecs.World, data ApplicationPayload, w http.ResponseWriter) {
+	propertyEntity, _, application, ok := findApplication(world, data, w)
+	if !ok {
+		return
+	}
+	if application.Screened {
+		utils.SendResponse(w, http.StatusBadRequest, "Applicant has already been screened", nil)
+		return
+	}
+	if !checkShareholderFunds(world, propertyEntity, components.ScreeningFee, w) {
+		return
+	}
+
+	gameTime, _ := world.GetCurrentGameTime()
+	systems.ScreenApplication(world, propertyEntity, application, gameTime.CurrentDate)
+	utils.SendResponse(w, http.StatusOK, "Applicant screened successfully", application)
+}
+
+// handleAcceptApplication moves the applicant into the unit they applied for and turns the unit's other applicants away.
+func handleAcceptApplication(world *ecs.World, data ApplicationPayload, w http.ResponseWriter) {
+	propertyEntity, unit, application, ok := findApplication(world, data, w)
+	if !ok {
+		return
+	}
+
+	gameTime, _ := world.GetCurrentGameTime()
+	systems.AcceptApplication(world, propertyEntity, unit, application, gameTime.CurrentDate)
+	utils.SendResponse(w, http.StatusOK, "Application accepted successfully", unit)
+}
+
+// handleRejectApplication turns the applicant away.
+func handleRejectApplication(world *ecs.World, data ApplicationPayload, w http.ResponseWriter) {
+	_, unit, application, ok := findApplication(world, data, w)
+	if !ok {
+		return
+	}
+
+	systems.RejectApplication(unit, application)
+	utils.SendResponse(w, http.StatusOK, "Application rejected successfully", unit.Applications)
+}
+
+// findApplication looks up an open application for a vacant unit of a property, responding with an error
+// and returning false if there isn't one or its owners can't act on it.
+func findApplication(world *ecs.World, data ApplicationPayload, w http.ResponseWriter) (*ecs.Entity, *components.Unit, *components.TenantApplication, bool) {
+	propertyEntity := world.GetEntity(data.PropertyID)
+	if propertyEntity == nil {
+		utils.SendResponse(w, http.StatusNotFound, "Property not found", nil)
+		return nil, nil, nil, false
+	}
+	ownable, _ := propertyEntity.GetOwnable()
+	if ownable == nil || !ownable.Owned {
+		utils.SendResponse(w, http.StatusBadRequest, "Property is not owned", nil)
+		return nil, nil, nil, false
+	}
+	if !checkNotBankrupt(world.GetEntity(ownable.OwnerID), w) {
+		return nil, nil, nil, false
+	}
+
+	rentable, err := propertyEntity.GetRentable()
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest, "Property is not rentable", nil)
+		return nil, nil, nil, false
+	}
+	unit, application := rentable.Application(data.ApplicationID)
+	if application == nil {
+		utils.SendResponse(w, http.StatusNotFound, "Application not found", nil)
+		return nil, nil, nil, false
+	}
+	if unit.Tenant != nil {
+		utils.SendResponse(w, http.StatusBadRequest, "Unit already has a tenant", nil)
+		return nil, nil, nil, false
+	}
+	return propertyEntity, unit, application, true
+}
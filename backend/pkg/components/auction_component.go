@@ -0,0 +1,81 @@
+package components
+
+import "time"
+
+type AuctionReason string
+
+const (
+	ListingAuction     AuctionReason = "Listing"     // An unowned property put up for auction
+	ForeclosureAuction AuctionReason = "Foreclosure" // A property liquidated from a player who could not cover their debts
+)
+
+type AuctionStatus string
+
+const (
+	AuctionScheduled AuctionStatus = "Scheduled" // Announced, bidding has not opened yet
+	AuctionOpen      AuctionStatus = "Open"
+)
+
+// Days between an auction being announced and bidding opening
+const AuctionNoticeDays = 7
+
+// Days bidding stays open
+const AuctionBiddingDays = 14
+
+// Reserve prices as a percentage of the market value when the auction is scheduled
+const (
+	ListingReservePercentage     = 90.0
+	ForeclosureReservePercentage = 70.0
+)
+
+// Minimum raise over the highest bid, as a percentage of the reserve price
+const BidIncrementPercentage = 1.0
+
+type Bid struct {
+	BidderID int
+	Amount   float64
+	Date     time.Time
+}
+
+// An auction for the property it is attached to.
+type Auction struct {
+	Reason       AuctionReason
+	Status       AuctionStatus
+	StartDate    time.Time // Bidding opens
+	EndDate      time.Time // Bidding closes and the auction is settled
+	ReservePrice float64   // Lowest price the property sells for
+	BidIncrement float64
+	Bids         []Bid
+}
+
+// HighestBid returns the highest bid placed so far, or nil if there are none.
+func (auction *Auction) HighestBid() *Bid {
+	if len(auction.Bids) == 0 {
+		return nil
+	}
+	return &auction.Bids[len(auction.Bids)-1]
+}
+
+// MinimumBid returns the lowest amount the next bid can be.
+func (auction *Auction) MinimumBid() float64 {
+	if highest := auction.HighestBid(); highest != nil {
+		return highest.Amount + auction.BidIncrement
+	}
+	return auction.ReservePrice
+}
+
+type AuctionResult struct {
+	PropertyID int
+	Reason     AuctionReason
+	Sold       bool
+	WinnerID   int
+	Price      float64
+	Date       time.Time
+}
+
+// The auction house schedules listing auctions and keeps a record of settled auctions.
+type AuctionHouse struct {
+	MonthlyListingChance float64 // Chance each month that the most valuable unowned property goes up for auction
+	LastScheduled        time.Time
+	Results              []AuctionResult
+}
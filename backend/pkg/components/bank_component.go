@@ -0,0 +1,40 @@
+package components
+
+import "time"
+
+// Policy rate when the game starts
+const InitialPolicyRate = 0.03
+
+// The central bank sets the policy rate that mortgage, savings and credit line rates are based on.
+type CentralBank struct {
+	Rate             float64                   // Annual policy rate, e.g. 0.03 for 3%
+	PhaseRates       map[EconomicPhase]float64 // Policy rate targeted during each economic phase
+	SavingsSpread    float64                   // Savings accounts earn the policy rate minus this spread
+	CreditLineSpread float64                   // Credit lines are charged the policy rate plus this spread
+	MaxLoanToValue   float64                   // Percentage of unencumbered property value that can be borrowed on a credit line
+	LastUpdated      time.Time
+}
+
+func (bank *CentralBank) SavingsRate() float64 {
+	if bank.Rate < bank.SavingsSpread {
+		return 0
+	}
+	return bank.Rate - bank.SavingsSpread
+}
+
+func (bank *CentralBank) CreditLineRate() float64 {
+	return bank.Rate + bank.CreditLineSpread
+}
+
+// MortgageRate returns the annual rate for a new mortgage with the given spread.
+func (bank *CentralBank) MortgageRate(spread float64) float64 {
+	return bank.Rate + spread
+}
+
+// A player's savings account and revolving credit line.
+type BankAccount struct {
+	SavingsBalance    float64
+	CreditLineBalance float64
+	CreditLimit       float64 // Recalculated monthly from the value of the player's properties
+	LastInterestDate  time.Time
+}
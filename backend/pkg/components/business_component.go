@@ -0,0 +1,181 @@
+package components
+
+import "time"
+
+type BusinessCategory string
+
+const (
+	RetailBusiness        BusinessCategory = "Retail"
+	FoodAndBeverage       BusinessCategory = "FoodAndBeverage"
+	ServiceBusiness       BusinessCategory = "Services"
+	EntertainmentBusiness BusinessCategory = "Entertainment"
+	OfficeBusiness        BusinessCategory = "Office"
+	IndustrialBusiness    BusinessCategory = "Industrial"
+)
+
+// Share of sales above which rent leaves a business struggling to cover its other costs
+const MaxOccupancyCostRatio = 0.15
+
+// Consecutive struggling months after which a business closes
+const BusinessFailureMonths = 6
+
+// Monthly chance that a business closes for reasons of its own, raised by each month it has been struggling
+const BusinessClosureChance = 0.002
+
+// How a category of business trades.
+type BusinessProfile struct {
+	SalesToRent            float64     // Average monthly sales as a multiple of the standard rent of the space
+	FootTrafficSensitivity float64     // How strongly sales follow neighborhood foot traffic, from 0 to 1
+	PercentageRent         float64     // Percentage of sales above the lease's breakpoint paid on top of base rent
+	Seasonality            [12]float64 // Sales multiplier for each month from January to December
+}
+
+var BusinessProfiles = map[BusinessCategory]BusinessProfile{
+	RetailBusiness: {
+		SalesToRent:            14,
+		FootTrafficSensitivity: 1,
+		PercentageRent:         7,
+		Seasonality:            [12]float64{0.85, 0.85, 0.95, 0.95, 1, 0.95, 0.95, 1, 0.95, 1, 1.1, 1.45},
+	},
+	FoodAndBeverage: {
+		SalesToRent:            13,
+		FootTrafficSensitivity: 0.8,
+		PercentageRent:         8,
+		Seasonality:            [12]float64{0.9, 0.9, 1, 1, 1.05, 1.1, 1.1, 1.1, 1, 1, 0.95, 0.9},
+	},
+	ServiceBusiness: {
+		SalesToRent:            11,
+		FootTrafficSensitivity: 0.4,
+		PercentageRent:         6,
+		Seasonality:            [12]float64{0.95, 0.95, 1, 1, 1, 1, 1, 0.95, 1, 1.05, 1.05, 1.05},
+	},
+	EntertainmentBusiness: {
+		SalesToRent:            12,
+		FootTrafficSensitivity: 0.9,
+		PercentageRent:         8,
+		Seasonality:            [12]float64{0.9, 0.85, 0.95, 0.95, 1, 1.15, 1.2, 1.15, 0.9, 0.95, 0.95, 1.05},
+	},
+	OfficeBusiness: {
+		SalesToRent:            20,
+		FootTrafficSensitivity: 0.1,
+		PercentageRent:         0,
+		Seasonality:            [12]float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+	},
+	IndustrialBusiness: {
+		SalesToRent:            20,
+		FootTrafficSensitivity: 0,
+		PercentageRent:         0,
+		Seasonality:            [12]float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+	},
+}
+
+// Seasonality for subtypes whose trade doesn't follow their category
+var SubtypeSeasonality = map[PropertySubtype][12]float64{
+	IceCreamShop:  {0.5, 0.5, 0.8, 1, 1.3, 1.6, 1.7, 1.6, 1.1, 0.8, 0.6, 0.5},
+	Florist:       {0.9, 1.6, 0.9, 0.9, 1.5, 1, 0.8, 0.8, 0.8, 0.8, 0.9, 1.1},
+	JewelryStore:  {0.7, 1.3, 0.8, 0.8, 1.1, 0.9, 0.8, 0.8, 0.8, 0.9, 1, 2.1},
+	AmusementPark: {0.3, 0.3, 0.6, 0.9, 1.2, 1.8, 2, 1.9, 1.2, 0.9, 0.5, 0.4},
+}
+
+// The kind of business each commercial subtype is
+var SubtypeBusinessCategories = map[PropertySubtype]BusinessCategory{
+	Bookstore:        RetailBusiness,
+	ClothingStore:    RetailBusiness,
+	ConvenienceStore: RetailBusiness,
+	ElectronicsStore: RetailBusiness,
+	Florist:          RetailBusiness,
+	FurnitureStore:   RetailBusiness,
+	JewelryStore:     RetailBusiness,
+	LiquorStore:      RetailBusiness,
+	Mall:             RetailBusiness,
+	PetStore:         RetailBusiness,
+	Pharmacy:         RetailBusiness,
+	ShoeStore:        RetailBusiness,
+	Supermarket:      RetailBusiness,
+
+	Bakery:            FoodAndBeverage,
+	Bar:               FoodAndBeverage,
+	Brewery:           FoodAndBeverage,
+	Cafe:              FoodAndBeverage,
+	IceCreamShop:      FoodAndBeverage,
+	Microbrewery:      FoodAndBeverage,
+	NightClub:         FoodAndBeverage,
+	Restaurant:        FoodAndBeverage,
+	Winery:            FoodAndBeverage,
+	WineryTastingRoom: FoodAndBeverage,
+
+	AutoRepairShop:   ServiceBusiness,
+	CarWash:          ServiceBusiness,
+	Clinic:           ServiceBusiness,
+	DaycareCenter:    ServiceBusiness,
+	DryCleaners:      ServiceBusiness,
+	GasStation:       ServiceBusiness,
+	Gym:              ServiceBusiness,
+	Hotel:            ServiceBusiness,
+	MedicalOffice:    ServiceBusiness,
+	Salon:            ServiceBusiness,
+	Spa:              ServiceBusiness,
+	TattooParlor:     ServiceBusiness,
+	VeterinaryClinic: ServiceBusiness,
+	FitnessCenter:    ServiceBusiness,
+
+	AmusementPark: EntertainmentBusiness,
+	Arcade:        EntertainmentBusiness,
+	ArtGallery:    EntertainmentBusiness,
+	BowlingAlley:  EntertainmentBusiness,
+	ConcertHall:   EntertainmentBusiness,
+	EventVenue:    EntertainmentBusiness,
+	GamingCenter:  EntertainmentBusiness,
+	MovieTheater:  EntertainmentBusiness,
+	Museum:        EntertainmentBusiness,
+	SportsArena:   EntertainmentBusiness,
+	Theater:       EntertainmentBusiness,
+
+	AccountingFirm:    OfficeBusiness,
+	ArchitecturalFirm: OfficeBusiness,
+	ConsultingFirm:    OfficeBusiness,
+	CoWorkingSpace:    OfficeBusiness,
+	CreditUnion:       OfficeBusiness,
+	EngineeringFirm:   OfficeBusiness,
+	InsuranceFirm:     OfficeBusiness,
+	InvestmentBank:    OfficeBusiness,
+	LawOffice:         OfficeBusiness,
+	MortgageBank:      OfficeBusiness,
+	RealEstateOffice:  OfficeBusiness,
+	ResearchLab:       OfficeBusiness,
+	TechHub:           OfficeBusiness,
+	EducationalCenter: OfficeBusiness,
+	GreenBuilding:     OfficeBusiness,
+
+	DataCenter:         IndustrialBusiness,
+	DistributionCenter: IndustrialBusiness,
+	Factory:            IndustrialBusiness,
+	ParkingGarage:      IndustrialBusiness,
+	RecyclingCenter:    IndustrialBusiness,
+	StorageFacility:    IndustrialBusiness,
+}
+
+// The business run by a commercial tenant.
+type Business struct {
+	Category                BusinessCategory
+	Subtype                 PropertySubtype
+	Quality                 float64 `json:"-"` // How well the business is run: sales multiplier around 1, hidden from players
+	LastMonthSales          float64
+	LastMonthPercentageRent float64 // Percentage rent on last month's sales
+	PercentageRentDue       float64 // Percentage rent not yet charged with the month's rent
+	MonthsStruggling        int     // Consecutive months rent took more than the maximum share of sales
+	LastUpdated             time.Time
+	Closed                  bool // Closed down; the tenant moves out once its final rent has been charged
+}
+
+// Profile returns how the business trades, with any seasonality specific to its subtype.
+func (business *Business) Profile() BusinessProfile {
+	profile, ok := BusinessProfiles[business.Category]
+	if !ok {
+		profile = BusinessProfiles[RetailBusiness]
+	}
+	if seasonality, ok := SubtypeSeasonality[business.Subtype]; ok {
+		profile.Seasonality = seasonality
+	}
+	return profile
+}
@@ -0,0 +1,6 @@
+package components
+
+// How attractive a neighborhood is to tenants.
+type Desirability struct {
+	Value float64 // 0-100 scale, 50 is average
+}
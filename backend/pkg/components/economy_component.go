@@ -0,0 +1,34 @@
+package components
+
+import "time"
+
+type EconomicPhase string
+
+const (
+	Expansion EconomicPhase = "Expansion"
+	Peak      EconomicPhase = "Peak"
+	Recession EconomicPhase = "Recession"
+	Recovery  EconomicPhase = "Recovery"
+)
+
+// How the economy behaves during a phase and when it moves on to the next one.
+type PhaseSettings struct {
+	RentMultiplier     float64 // Target multiplier applied to all rents
+	VacancyRate        float64 // Percentage of rent lost to vacancies
+	MonthlyPriceGrowth float64 // Percentage change of the property price index per month
+	MinMonths          int     // Months before the phase can end
+	MaxMonths          int     // Months after which the phase always ends
+	TransitionChance   float64 // Monthly chance of ending the phase once MinMonths have passed
+	Next               EconomicPhase
+}
+
+// The state of the macro-economy, shared by every property and player.
+type Economy struct {
+	Phase          EconomicPhase
+	MonthsInPhase  int
+	RentMultiplier float64
+	VacancyRate    float64
+	PriceIndex     float64 // 100 at the start of the game
+	LastUpdated    time.Time
+	Phases         map[EconomicPhase]PhaseSettings
+}
@@ -0,0 +1,50 @@
+package components
+
+import "time"
+
+type IncomeStatement struct {
+	Lines         map[TransactionCategory]float64
+	TotalRevenue  float64
+	TotalExpenses float64 // Reported as a negative amount
+	NetIncome     float64
+}
+
+type CashFlowStatement struct {
+	Operating         map[TransactionCategory]float64
+	Investing         map[TransactionCategory]float64
+	Financing         map[TransactionCategory]float64
+	OperatingCashFlow float64
+	InvestingCashFlow float64
+	FinancingCashFlow float64
+	NetCashFlow       float64
+}
+
+// A snapshot of a player's assets and liabilities at the end of a period.
+type BalanceSheet struct {
+	Cash              float64
+	Savings           float64
+	PropertyValue     float64
+	TotalAssets       float64
+	LoanBalances      float64
+	CreditLineBalance float64
+	TaxesPayable      float64
+	SecurityDeposits  float64 // Deposits held for tenants, refundable when they move out
+	TotalLiabilities  float64
+	Equity            float64
+}
+
+type FinancialStatement struct {
+	PeriodStart  time.Time
+	PeriodEnd    time.Time
+	Income       IncomeStatement
+	CashFlow     CashFlowStatement
+	BalanceSheet BalanceSheet
+	// Inflation index at closing; dividing by it restates the figures in start of game dollars
+	InflationIndex float64
+}
+
+// Monthly financial statements closed for a player.
+type FinancialStatements struct {
+	OpenPeriodStart time.Time // First day of the month that has not been closed yet
+	Monthly         []*FinancialStatement
+}
@@ -1,7 +1,10 @@
-	package components
+package components
+
+import "time"
 
 // This can be attached to tenants, customers, or any entity affected by conditions.
 type Happiness struct {
-	Value float64 // 0-100 scale
-	// Could add other fields like a list of modifiers or reason codes
+	Value       float64            // 0-100 scale
+	Factors     map[string]float64 // Points each factor added to or took from the value
+	LastUpdated time.Time
 }
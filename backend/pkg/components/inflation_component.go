@@ -0,0 +1,11 @@
+package components
+
+import "time"
+
+// General inflation, compounded once a year and applied to rents, costs and prices.
+type Inflation struct {
+	AnnualRate  float64                   // Rate applied at the next compounding, e.g. 0.025 for 2.5%
+	PhaseRates  map[EconomicPhase]float64 // Rate set for the coming year by the economic phase at compounding time
+	Index       float64                   // Cumulative price level, 1.0 at the start of the game
+	LastUpdated time.Time                 // Start of the year the index was last compounded for
+}
@@ -0,0 +1,72 @@
+package components
+
+import "time"
+
+// The lease rules for a type of property.
+type LeaseTerms struct {
+	TermMonths                   int     // Length of a new lease
+	RenewalTermMonths            int     // Length of a renewed lease
+	AnnualEscalationPercentage   float64 // Rent increase on each anniversary of the lease
+	MaxRenewalIncreasePercentage float64 // Largest rent increase a tenant accepts on renewal
+	RenewalChance                float64 // Chance the tenant renews when the lease ends
+	Breakable                    bool    // Whether the tenant can move out before the lease ends
+	EarlyTerminationFeeMonths    float64 // Months of rent a tenant pays to break the lease
+}
+
+// Lease terms offered to new tenants by property type
+var StandardLeaseTerms = map[PropertyType]LeaseTerms{
+	Residential: {
+		TermMonths:                   12,
+		RenewalTermMonths:            12,
+		AnnualEscalationPercentage:   0,
+		MaxRenewalIncreasePercentage: 5,
+		RenewalChance:                0.6,
+		Breakable:                    true,
+		EarlyTerminationFeeMonths:    1,
+	},
+	Commercial: {
+		TermMonths:                   60,
+		RenewalTermMonths:            36,
+		AnnualEscalationPercentage:   3,
+		MaxRenewalIncreasePercentage: 10,
+		RenewalChance:                0.75,
+		Breakable:                    false,
+		EarlyTerminationFeeMonths:    0,
+	},
+}
+
+// A tenant's lease, with the rent locked in when it was signed.
+type Lease struct {
+	Type                       PropertyType
+	StartDate                  time.Time
+	EndDate                    time.Time
+	TermMonths                 int
+	MonthlyRent                float64 // Rent agreed at signing, before escalations
+	AnnualEscalationPercentage float64
+	PercentageRent             float64 // Percentage of sales above the breakpoint due on top of the rent; 0 for fixed rent
+	Renewals                   int
+}
+
+// RentOn returns the monthly rent due under the lease on the given date, including escalations
+// for every anniversary of the lease that has passed.
+func (lease *Lease) RentOn(date time.Time) float64 {
+	rent := lease.MonthlyRent
+	for anniversary := lease.StartDate.AddDate(1, 0, 0); !anniversary.After(date); anniversary = anniversary.AddDate(1, 0, 0) {
+		rent *= 1 + lease.AnnualEscalationPercentage/100
+	}
+	return rent
+}
+
+// Terms returns the standard terms for the lease's property type.
+func (lease *Lease) Terms() LeaseTerms {
+	return StandardLeaseTerms[lease.Type]
+}
+
+// Breakpoint returns the monthly sales above which percentage rent is due on the given date:
+// the natural breakpoint, at which the percentage of sales equals the base rent.
+func (lease *Lease) Breakpoint(date time.Time) float64 {
+	if lease.PercentageRent <= 0 {
+		return 0
+	}
+	return lease.RentOn(date) / (lease.PercentageRent / 100)
+}
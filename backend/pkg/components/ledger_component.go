@@ -0,0 +1,107 @@
+package components
+
+import "time"
+
+type TransactionCategory string
+
+const (
+	RentIncome          TransactionCategory = "RentIncome"
+	PropertyPurchase    TransactionCategory = "PropertyPurchase"
+	SaleProceeds        TransactionCategory = "SaleProceeds"
+	UpgradeSpend        TransactionCategory = "UpgradeSpend"
+	LoanProceeds        TransactionCategory = "LoanProceeds"
+	LoanPrincipal       TransactionCategory = "LoanPrincipal"
+	LoanInterest        TransactionCategory = "LoanInterest"
+	PropertyTax         TransactionCategory = "PropertyTax"
+	MaintenanceSpend    TransactionCategory = "MaintenanceSpend"
+	Utilities           TransactionCategory = "Utilities"
+	Insurance           TransactionCategory = "Insurance"
+	ManagementFees      TransactionCategory = "ManagementFees"
+	VacancyReserve      TransactionCategory = "VacancyReserve"
+	SavingsDeposit      TransactionCategory = "SavingsDeposit"
+	SavingsWithdrawal   TransactionCategory = "SavingsWithdrawal"
+	SavingsInterest     TransactionCategory = "SavingsInterest"
+	CreditLineDraw      TransactionCategory = "CreditLineDraw"
+	CreditLineRepayment TransactionCategory = "CreditLineRepayment"
+	CreditLineInterest  TransactionCategory = "CreditLineInterest"
+	CapitalGainsTax     TransactionCategory = "CapitalGainsTax"
+	LateFeeIncome       TransactionCategory = "LateFeeIncome"
+	SecurityDeposit     TransactionCategory = "SecurityDeposit"
+	DepositRefund       TransactionCategory = "DepositRefund"
+	SharePurchase       TransactionCategory = "SharePurchase"
+	ShareSale           TransactionCategory = "ShareSale"
+	PlayerTransfer      TransactionCategory = "PlayerTransfer"
+	LeaseBreakFee       TransactionCategory = "LeaseBreakFee"
+	ScreeningFees       TransactionCategory = "ScreeningFees"
+)
+
+type CashFlowActivity string
+
+const (
+	OperatingActivity CashFlowActivity = "Operating"
+	InvestingActivity CashFlowActivity = "Investing"
+	FinancingActivity CashFlowActivity = "Financing"
+)
+
+// The cash flow statement section each transaction category is reported in
+var TransactionActivities = map[TransactionCategory]CashFlowActivity{
+	RentIncome:          OperatingActivity,
+	LoanInterest:        OperatingActivity,
+	PropertyTax:         OperatingActivity,
+	MaintenanceSpend:    OperatingActivity,
+	Utilities:           OperatingActivity,
+	Insurance:           OperatingActivity,
+	ManagementFees:      OperatingActivity,
+	VacancyReserve:      OperatingActivity,
+	SavingsInterest:     OperatingActivity,
+	CreditLineInterest:  OperatingActivity,
+	CapitalGainsTax:     OperatingActivity,
+	LateFeeIncome:       OperatingActivity,
+	LeaseBreakFee:       OperatingActivity,
+	ScreeningFees:       OperatingActivity,
+	PropertyPurchase:    InvestingActivity,
+	SaleProceeds:        InvestingActivity,
+	UpgradeSpend:        InvestingActivity,
+	SavingsDeposit:      InvestingActivity,
+	SavingsWithdrawal:   InvestingActivity,
+	SharePurchase:       InvestingActivity,
+	ShareSale:           InvestingActivity,
+	LoanProceeds:        FinancingActivity,
+	LoanPrincipal:       FinancingActivity,
+	CreditLineDraw:      FinancingActivity,
+	CreditLineRepayment: FinancingActivity,
+	SecurityDeposit:     FinancingActivity,
+	DepositRefund:       FinancingActivity,
+	PlayerTransfer:      FinancingActivity,
+}
+
+// Transaction categories reported as revenue or expenses on the income statement
+var IncomeStatementCategories = map[TransactionCategory]bool{
+	RentIncome:         true,
+	LoanInterest:       true,
+	PropertyTax:        true,
+	MaintenanceSpend:   true,
+	Utilities:          true,
+	Insurance:          true,
+	ManagementFees:     true,
+	VacancyReserve:     true,
+	SavingsInterest:    true,
+	CreditLineInterest: true,
+	CapitalGainsTax:    true,
+	LateFeeIncome:      true,
+	LeaseBreakFee:      true,
+	ScreeningFees:      true,
+}
+
+type Transaction struct {
+	Date        time.Time
+	Category    TransactionCategory
+	Amount      float64 // Positive for money received, negative for money spent
+	PropertyID  int     // 0 when the transaction is not tied to a property
+	Description string
+}
+
+// A record of every change to an entity's funds.
+type Ledger struct {
+	Transactions []Transaction
+}
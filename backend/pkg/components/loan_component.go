@@ -0,0 +1,52 @@
+package components
+
+import (
+	"math"
+	"time"
+)
+
+// Minimum percentage of the purchase price that must be paid in cash when financing a property
+const MinDownPaymentPercentage = 20.0
+
+// Loan products offered when financing a purchase: term in months -> spread over the central bank rate
+var LoanOffers = map[int]float64{
+	120: 0.0225,
+	180: 0.0250,
+	240: 0.0300,
+	360: 0.0350,
+}
+
+// A mortgage secured by the property it is attached to.
+type Loan struct {
+	BorrowerID           int
+	Principal            float64 // Amount originally borrowed
+	OutstandingPrincipal float64 // Principal still owed
+	AnnualRate           float64 // e.g. 0.065 for 6.5%
+	TermMonths           int
+	MonthlyPayment       float64
+	StartDate            time.Time
+	NextPaymentDate      time.Time
+	PaymentsMade         int
+	TotalInterestPaid    float64
+}
+
+// AmortizedPayment returns the fixed monthly payment that pays off principal over termMonths.
+func AmortizedPayment(principal float64, annualRate float64, termMonths int) float64 {
+	if termMonths <= 0 {
+		return principal
+	}
+	monthlyRate := annualRate / 12
+	if monthlyRate == 0 {
+		return principal / float64(termMonths)
+	}
+	return principal * monthlyRate / (1 - math.Pow(1+monthlyRate, -float64(termMonths)))
+}
+
+// NextInterest returns the interest accrued on the outstanding principal over one month.
+func (loan *Loan) NextInterest() float64 {
+	return loan.OutstandingPrincipal * loan.AnnualRate / 12
+}
+
+func (loan *Loan) IsPaidOff() bool {
+	return loan.OutstandingPrincipal <= 0.005
+}
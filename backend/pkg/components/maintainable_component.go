@@ -1,7 +1,10 @@
 package components
 
+import "time"
+
 type Maintainable struct {
-	Condition      float64  // 0-100, where 100 is perfect
-	MaintenanceDue float64  // Accumulated maintenance cost not yet addressed
-	DamageEvents   []string // e.g., "HVAC_Break", "Roof_Leak"
+	Condition      float64   // 0-100, where 100 is perfect
+	MaintenanceDue float64   // Accumulated maintenance cost not yet addressed
+	DamageEvents   []string  // e.g., "HVAC_Break", "Roof_Leak"
+	LastUpdated    time.Time // Last time wear and damage were simulated
 }
@@ -0,0 +1,25 @@
+package components
+
+import "time"
+
+// Price and rent levels of a neighborhood, tracked monthly relative to the first reading.
+type MarketIndex struct {
+	PriceIndex  float64            // Average market value per property, 100 at the first reading
+	RentIndex   float64            // Average market rent per property, 100 at the first reading
+	BasePrice   float64            // Average market value at the first reading
+	BaseRent    float64            // Average market rent at the first reading
+	LocalFactor float64            // The neighborhood's own appreciation on top of the economy, 1.0 at the start
+	Volatility  float64            // Standard deviation of the monthly local price shock, in percent
+	History     []MarketIndexPoint // One reading per month, oldest first
+	LastUpdated time.Time
+}
+
+// A monthly reading of a neighborhood's market index.
+type MarketIndexPoint struct {
+	Date         time.Time `json:"date"`
+	PriceIndex   float64   `json:"price_index"`
+	RentIndex    float64   `json:"rent_index"`
+	AveragePrice float64   `json:"average_price"`
+	AverageRent  float64   `json:"average_rent"`
+	LocalFactor  float64   `json:"local_factor"`
+}
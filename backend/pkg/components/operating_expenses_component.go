@@ -0,0 +1,57 @@
+package components
+
+import "time"
+
+type OperatingExpenseRates struct {
+	MonthlyUtilities         float64 // Fixed utilities cost per unit per month
+	AnnualInsuranceRate      float64 // Percentage of the property value per year
+	ManagementFeePercentage  float64 // Percentage of collected rent
+	VacancyReservePercentage float64 // Percentage of collected rent
+}
+
+// Operating expense rates by property type
+var TypeOperatingExpenseRates = map[PropertyType]OperatingExpenseRates{
+	Residential: {MonthlyUtilities: 150, AnnualInsuranceRate: 0.35, ManagementFeePercentage: 8, VacancyReservePercentage: 5},
+	Commercial:  {MonthlyUtilities: 600, AnnualInsuranceRate: 0.5, ManagementFeePercentage: 4, VacancyReservePercentage: 8},
+}
+
+// Operating expense rates for subtypes that differ from their property type
+var SubtypeOperatingExpenseRates = map[PropertySubtype]OperatingExpenseRates{
+	Apartment:   {MonthlyUtilities: 250, AnnualInsuranceRate: 0.4, ManagementFeePercentage: 10, VacancyReservePercentage: 6},
+	Multifamily: {MonthlyUtilities: 300, AnnualInsuranceRate: 0.4, ManagementFeePercentage: 10, VacancyReservePercentage: 6},
+	Penthouse:   {MonthlyUtilities: 300, AnnualInsuranceRate: 0.3, ManagementFeePercentage: 6, VacancyReservePercentage: 4},
+	Bakery:      {MonthlyUtilities: 1200, AnnualInsuranceRate: 0.6, ManagementFeePercentage: 4, VacancyReservePercentage: 8},
+	Cafe:        {MonthlyUtilities: 1000, AnnualInsuranceRate: 0.6, ManagementFeePercentage: 4, VacancyReservePercentage: 8},
+	Restaurant:  {MonthlyUtilities: 1500, AnnualInsuranceRate: 0.7, ManagementFeePercentage: 4, VacancyReservePercentage: 10},
+	Bar:         {MonthlyUtilities: 1000, AnnualInsuranceRate: 0.8, ManagementFeePercentage: 4, VacancyReservePercentage: 10},
+	NightClub:   {MonthlyUtilities: 1500, AnnualInsuranceRate: 1.0, ManagementFeePercentage: 5, VacancyReservePercentage: 12},
+	Gym:         {MonthlyUtilities: 1200, AnnualInsuranceRate: 0.6, ManagementFeePercentage: 4, VacancyReservePercentage: 8},
+	Arcade:      {MonthlyUtilities: 1400, AnnualInsuranceRate: 0.5, ManagementFeePercentage: 4, VacancyReservePercentage: 10},
+	Hotel:       {MonthlyUtilities: 4000, AnnualInsuranceRate: 0.6, ManagementFeePercentage: 20, VacancyReservePercentage: 15},
+	Mall:        {MonthlyUtilities: 8000, AnnualInsuranceRate: 0.5, ManagementFeePercentage: 5, VacancyReservePercentage: 10},
+	DataCenter:  {MonthlyUtilities: 15000, AnnualInsuranceRate: 0.6, ManagementFeePercentage: 3, VacancyReservePercentage: 5},
+	Factory:     {MonthlyUtilities: 5000, AnnualInsuranceRate: 0.9, ManagementFeePercentage: 3, VacancyReservePercentage: 8},
+}
+
+// The operating expenses charged against a property's rent for one month.
+type OperatingExpenseBreakdown struct {
+	PeriodStart   time.Time
+	PeriodEnd     time.Time
+	GrossRent     float64
+	Expenses      map[TransactionCategory]float64
+	TotalExpenses float64
+	NetRent       float64
+}
+
+type OperatingExpenses struct {
+	Rates     OperatingExpenseRates
+	LastMonth *OperatingExpenseBreakdown
+}
+
+// OperatingExpenseRatesFor returns the subtype's rates if it has its own, otherwise the rates for its type.
+func OperatingExpenseRatesFor(propertyType PropertyType, subtype PropertySubtype) OperatingExpenseRates {
+	if rates, ok := SubtypeOperatingExpenseRates[subtype]; ok {
+		return rates
+	}
+	return TypeOperatingExpenseRates[propertyType]
+}
@@ -1,6 +1,57 @@
 package components
 
+import "time"
+
 type Ownable struct {
-    OwnerID int
-    Owned   bool
+	OwnerID     int // Managing owner: the largest shareholder, who runs the property
+	Owned       bool
+	Shares      map[int]float64 // Player ID -> percentage of the property owned; empty when OwnerID owns all of it
+	ShareOffers []ShareOffer    // Shares listed for sale to other players
+}
+
+// Shares of a property a shareholder is offering to sell.
+type ShareOffer struct {
+	SellerID   int
+	BuyerID    int // Only this player can buy the shares; 0 for any player
+	Percentage float64
+	Price      float64
+	Forced     bool      // Listed by the bankruptcy system to cover the seller's negative funds; can't be withdrawn
+	Deadline   time.Time // Forced offers unsold by this date are forfeited to the other shareholders
+}
+
+// Shareholders returns the percentage of the property owned by each of its owners.
+func (ownable *Ownable) Shareholders() map[int]float64 {
+	if !ownable.Owned {
+		return map[int]float64{}
+	}
+	if len(ownable.Shares) == 0 {
+		return map[int]float64{ownable.OwnerID: 100}
+	}
+	return ownable.Shares
+}
+
+// Share returns the percentage of the property owned by the player.
+func (ownable *Ownable) Share(playerID int) float64 {
+	return ownable.Shareholders()[playerID]
+}
+
+// ForcedOffer returns the shares the bankruptcy system has put up for sale on the player's behalf, or nil if there are none.
+func (ownable *Ownable) ForcedOffer(playerID int) *ShareOffer {
+	for i := range ownable.ShareOffers {
+		if offer := &ownable.ShareOffers[i]; offer.Forced && offer.SellerID == playerID {
+			return offer
+		}
+	}
+	return nil
+}
+
+// OfferedShare returns the percentage of the property the player has listed for sale.
+func (ownable *Ownable) OfferedShare(playerID int) float64 {
+	offered := 0.0
+	for _, offer := range ownable.ShareOffers {
+		if offer.SellerID == playerID {
+			offered += offer.Percentage
+		}
+	}
+	return offered
 }
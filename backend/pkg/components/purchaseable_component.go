@@ -6,3 +6,18 @@ type Purchaseable struct {
 	Cost         float64
 	PurchaseDate time.Time
 }
+
+// Capital gains on property sales are taxed at the short-term rate unless the property was held for at least a year
+const (
+	ShortTermCapitalGainsRate = 0.30
+	LongTermCapitalGainsRate  = 0.15
+)
+
+// Percentage of the purchase price attributed to the building rather than the land; only the building depreciates
+const BuildingValuePercentage = 80.0
+
+// Straight-line depreciation period in years by property type
+var DepreciationYears = map[PropertyType]float64{
+	Residential: 27.5,
+	Commercial:  39,
+}
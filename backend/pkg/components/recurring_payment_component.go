@@ -0,0 +1,67 @@
+package components
+
+import "time"
+
+type PaymentFrequency string
+
+const (
+	MonthlyFrequency   PaymentFrequency = "Monthly"
+	QuarterlyFrequency PaymentFrequency = "Quarterly"
+	YearlyFrequency    PaymentFrequency = "Yearly"
+)
+
+// Months between due dates for each payment frequency
+var PaymentFrequencyMonths = map[PaymentFrequency]int{
+	MonthlyFrequency:   1,
+	QuarterlyFrequency: 3,
+	YearlyFrequency:    12,
+}
+
+// What happens when the payer cannot afford a payment on its due date.
+type PaymentFailurePolicy string
+
+const (
+	SkipFailedPayment   PaymentFailurePolicy = "Skip"   // The payment is missed and never made up
+	AccrueFailedPayment PaymentFailurePolicy = "Accrue" // The amount is added to what is due on the next due date
+	CancelOnFailure     PaymentFailurePolicy = "Cancel" // The obligation ends
+	DebitOnFailure      PaymentFailurePolicy = "Debit"  // The payment is made anyway and may leave the payer's funds negative; mortgages only
+)
+
+// Payer or payee outside the game, such as a tenant, utility company or government
+const ExternalParty = 0
+
+// An amount paid from one party to another on a schedule.
+type RecurringPayment struct {
+	Description         string
+	PayerID             int                 // Player or property paying, or ExternalParty
+	PayeeID             int                 // Player or property being paid, or ExternalParty
+	PropertyID          int                 // Property the payment is recorded against, 0 for none
+	Category            TransactionCategory // Ledger category posted on both sides
+	Amount              float64
+	Frequency           PaymentFrequency
+	StartDate           time.Time // First due date
+	EndDate             time.Time // No payments fall due after this date; zero for no end
+	NextDueDate         time.Time
+	FailurePolicy       PaymentFailurePolicy
+	AmountOverdue       float64 // Accrued from failed payments, due with the next payment
+	ConsecutiveFailures int
+	PaymentsMade        int
+	TotalPaid           float64
+	DueDatesPassed      int
+	Cancelled           bool
+	Mortgage            bool // Pays the mortgage on PropertyID, split into interest and principal, until the loan is paid off
+}
+
+// DueDate returns the nth due date after the start date, clamped to the end of shorter months.
+func (payment *RecurringPayment) DueDate(n int) time.Time {
+	months := PaymentFrequencyMonths[payment.Frequency] * n
+	start := payment.StartDate
+	firstOfMonth := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location()).AddDate(0, months, 0)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	return firstOfMonth.AddDate(0, 0, min(start.Day(), lastDay)-1)
+}
+
+// IsFinished reports whether the payment has been cancelled or has no due dates left.
+func (payment *RecurringPayment) IsFinished() bool {
+	return payment.Cancelled || (!payment.EndDate.IsZero() && payment.NextDueDate.After(payment.EndDate))
+}
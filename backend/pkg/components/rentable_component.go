@@ -3,7 +3,62 @@ package components
 import "time"
 
 type Rentable struct {
-	BaseRent               float64
+	BaseRent               float64 // Sum of the base rents of the property's units
 	RentBoost              float64 // Any applied rent boosts e.g. the neighborhood upgrade rent boost
 	LastRentCollectionDate time.Time
+	Units                  []*Unit
+	ApplicationsReceived   int // Applications received across the units over the property's life, used to number them
+}
+
+// A unit of a property let to its own tenant on its own lease. Single-unit properties have one unit.
+type Unit struct {
+	Number          int
+	BaseRent        float64
+	AskingRent      float64              // Monthly rent set by the owner; 0 charges the standard rent
+	Tenant          *Tenant              // nil while the unit is vacant
+	VacantSince     time.Time            // Start of the current vacancy; zero while occupied or not owned
+	ListedDate      time.Time            // When the vacant unit goes on the rental market
+	LastShowingDate time.Time            // Last day applications were rolled for while listed
+	Applications    []*TenantApplication // Open applications from prospective tenants while vacant
+}
+
+// Unit returns the unit with the given number, or nil if the property doesn't have one.
+func (rentable *Rentable) Unit(number int) *Unit {
+	for _, unit := range rentable.Units {
+		if unit.Number == number {
+			return unit
+		}
+	}
+	return nil
+}
+
+// OccupiedUnits returns the number of units with a tenant.
+func (rentable *Rentable) OccupiedUnits() int {
+	occupied := 0
+	for _, unit := range rentable.Units {
+		if unit.Tenant != nil {
+			occupied++
+		}
+	}
+	return occupied
+}
+
+// Application returns the open application with the given ID and the unit it is for, or nils if there isn't one.
+func (rentable *Rentable) Application(id int) (*Unit, *TenantApplication) {
+	for _, unit := range rentable.Units {
+		for _, application := range unit.Applications {
+			if application.ID == id {
+				return unit, application
+			}
+		}
+	}
+	return nil, nil
+}
+
+// UnitShare returns the unit's share of the property's rent.
+func (rentable *Rentable) UnitShare(unit *Unit) float64 {
+	if rentable.BaseRent <= 0 {
+		return 1 / float64(len(rentable.Units))
+	}
+	return unit.BaseRent / rentable.BaseRent
 }
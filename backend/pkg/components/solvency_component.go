@@ -0,0 +1,23 @@
+package components
+
+import "time"
+
+type SolvencyStatus string
+
+const (
+	Solvent  SolvencyStatus = "Solvent"
+	Warning  SolvencyStatus = "Warning"  // Funds are negative; assets are liquidated if the grace period runs out
+	Bankrupt SolvencyStatus = "Bankrupt" // Game over for the player
+)
+
+// Days a player can stay below zero funds before their assets are liquidated
+const BankruptcyGracePeriodDays = 30
+
+// Days a forced share sale stays open before the shares are forfeited, as long as a foreclosure auction runs
+const ForcedShareSaleDays = AuctionNoticeDays + AuctionBiddingDays
+
+type Solvency struct {
+	Status        SolvencyStatus
+	NegativeSince time.Time
+	Notices       []string // Warnings and liquidation records for the player
+}
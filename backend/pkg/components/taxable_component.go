@@ -0,0 +1,41 @@
+package components
+
+import "time"
+
+// Property tax configuration for a neighborhood (group).
+type TaxDistrict struct {
+	MillageRate     float64    // Tax per $1,000 of assessed value
+	DueMonth        time.Month // Month of the year bills are due
+	DueDay          int        // Day of the month bills are due
+	LatePenaltyRate float64    // Penalty charged on the unpaid balance for every month a bill is late, e.g. 0.015
+}
+
+// Days after assessment a bill is due when the property is assessed after the neighborhood's due date
+const LateAssessmentDueDays = 30
+
+type TaxBill struct {
+	OwnerID         int // Owner at assessment time, responsible for the bill even after a sale
+	Year            int
+	AssessedValue   float64
+	MillageRate     float64
+	Amount          float64
+	Penalty         float64
+	DueDate         time.Time
+	NextPenaltyDate time.Time
+	Paid            bool
+	PaidDate        time.Time
+}
+
+// Balance returns the amount still owed on the bill, including penalties.
+func (bill *TaxBill) Balance() float64 {
+	if bill.Paid {
+		return 0
+	}
+	return bill.Amount + bill.Penalty
+}
+
+type Taxable struct {
+	AssessedValue      float64
+	LastAssessmentYear int
+	Bills              []*TaxBill
+}
@@ -0,0 +1,54 @@
+package components
+
+import "time"
+
+// Days the owner has to answer an application before the property manager accepts it without screening
+const ApplicationResponseDays = 7
+
+// Most applications a vacant unit holds at once; prospective tenants stop applying while it is full
+const MaxOpenApplications = 5
+
+// Fee the owners pay to run a background and credit check on an applicant
+const ScreeningFee = 50.0
+
+// How much more often tenants accepted without screening miss or part-pay rent than screened tenants with the same profile
+const UnscreenedRiskMultiplier = 1.5
+
+// Share of income above which rent strains a tenant's budget and they miss or part-pay rent more often
+const MaxRentToIncomeRatio = 0.4
+
+// Lease lengths prospective tenants ask for by property type
+var ApplicantLeaseMonths = map[PropertyType][]int{
+	Residential: {6, 12, 24},
+	Commercial:  {36, 60, 120},
+}
+
+// An application from a prospective tenant to rent a vacant unit of a property.
+type TenantApplication struct {
+	ID            int
+	AppliedDate   time.Time
+	MonthlyIncome float64
+	Rent          float64 // Monthly rent the property was advertised at when they applied, which they will sign the lease at
+	DesiredRent   float64 // Monthly rent the applicant thinks the property is worth
+	LeaseMonths   int     // Length of lease the applicant wants to sign
+	Screened      bool
+	CreditScore   int     // Revealed by screening; 0 until the applicant is screened
+	CreditRisk    float64 `json:"-"` // 0 for the most reliable applicants up to 1 for the least; hidden from players
+}
+
+// PaymentRisk returns how many times more often than average the applicant would miss or part-pay rent as a tenant.
+func (application *TenantApplication) PaymentRisk() float64 {
+	risk := 0.5 + 1.5*application.CreditRisk
+	if application.MonthlyIncome > 0 && application.Rent/application.MonthlyIncome > MaxRentToIncomeRatio {
+		risk *= 1.5
+	}
+	if !application.Screened {
+		risk *= UnscreenedRiskMultiplier
+	}
+	return risk
+}
+
+// ScreenedCreditScore returns the credit score a background check finds for the applicant.
+func (application *TenantApplication) ScreenedCreditScore() int {
+	return int(850 - 550*application.CreditRisk)
+}
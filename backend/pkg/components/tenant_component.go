@@ -1,11 +1,45 @@
 package components
 
+import "time"
+
+// Months of rent collected as a security deposit when a tenant moves in
+const SecurityDepositMonths = 1.0
+
+// Late fee charged on rent that is still unpaid at the end of a month
+const LateFeePercentage = 5.0
+
+// Consecutive months in arrears after which a tenant is evicted
+const EvictionMonthsWithoutPay = 3
+
+// Monthly chance that a tenant breaks their lease and moves out at the start of a month, if their lease allows it
+const MonthlyMoveOutChance = 0.02
+
+// Chances that a tenant misses a month's payment entirely or only pays part of what they owe
+const (
+	MissedPaymentChance      = 0.03
+	PartialPaymentChance     = 0.07
+	PartialPaymentPercentage = 50.0
+)
+
 type Tenant struct {
-	Happiness        float64
-	RentDue          float64
-	MonthsWithoutPay int
+	Happiness        Happiness
+	RentDue          float64 // Rent charged but not yet paid
+	LateFeesDue      float64 // Late fees charged but not yet paid
+	MonthsWithoutPay int     // Consecutive months that ended with rent still owed
 	MoveOutChance    float64
 	DesiredRent      float64
+	PaymentRisk      float64 // Multiplier on the chances of missing or part-paying rent, set from their application
+	SecurityDeposit  float64 // Held by the owner until the tenant moves out
+	MoveInDate       time.Time
+	LastMoveOutRoll  time.Time // Start of the last month the tenant decided whether to move out
+	Lease            *Lease
+	PreviousLease    *Lease    // The lease the current one renewed, charged for the days of the month before the renewal
+	Business         *Business // The business a commercial tenant runs; nil for residential tenants
+}
+
+// Arrears returns the total the tenant owes, including late fees.
+func (tenant *Tenant) Arrears() float64 {
+	return tenant.RentDue + tenant.LateFeesDue
 }
 
 type TenantList struct {
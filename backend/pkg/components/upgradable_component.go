@@ -26,16 +26,17 @@ func (upgradable *Upgradable) CurrentUpgradeLevel(pathName string) int {
 		return 0
 	}
 
-	// Create a quick lookup for applied upgrades to improve efficiency
-	appliedSet := make(map[*Upgrade]bool, len(upgradable.AppliedUpgrades))
+	// Create a quick lookup for applied upgrades to improve efficiency.
+	// Applied upgrades are copies of the possible ones, so they are matched by name
+	appliedSet := make(map[string]bool, len(upgradable.AppliedUpgrades))
 	for _, applied := range upgradable.AppliedUpgrades {
-		appliedSet[applied] = true
+		appliedSet[applied.Name] = true
 	}
 
 	level := 0
 	// Count how many from this path are in the applied set
 	for _, upgrade := range pathUpgrades {
-		if appliedSet[upgrade] {
+		if appliedSet[upgrade.Name] {
 			level++
 		}
 	}
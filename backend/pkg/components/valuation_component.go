@@ -0,0 +1,13 @@
+package components
+
+import "time"
+
+// Percentage of the sale price lost to agent fees and closing costs when selling a property
+const SellingCostPercentage = 6.0
+
+type Valuation struct {
+	BaseValue         float64 // Listed value with no upgrades, in perfect condition, in a neutral market
+	MarketValue       float64 // Current value, used for buying, selling and assessments
+	LastValuationDate time.Time
+	Factors           map[string]float64 // Multipliers applied to the base value in the last valuation
+}
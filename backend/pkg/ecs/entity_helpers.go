@@ -1,6 +1,10 @@
 package ecs
 
-import "github.com/markbmullins/city-developer/pkg/components"
+import (
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+)
 
 func (e *Entity) GetFunds() (*components.Funds, error) {
 	component, err := e.GetComponent(&components.Funds{})
@@ -66,6 +70,138 @@ func (e *Entity) GetRentBoostable() (*components.RentBoostable, error) {
 	return component.(*components.RentBoostable), nil
 }
 
+func (e *Entity) GetLoan() (*components.Loan, error) {
+	component, err := e.GetComponent(&components.Loan{})
+	if err != nil {
+		return nil, err
+	}
+	return component.(*components.Loan), nil
+}
+
+func (e *Entity) GetTaxable() (*components.Taxable, error) {
+	component, err := e.GetComponent(&components.Taxable{})
+	if err != nil {
+		return nil, err
+	}
+	return component.(*components.Taxable), nil
+}
+
+func (e *Entity) GetTaxDistrict() (*components.TaxDistrict, error) {
+	component, err := e.GetComponent(&components.TaxDistrict{})
+	if err != nil {
+		return nil, err
+	}
+	return component.(*components.TaxDistrict), nil
+}
+
+func (e *Entity) GetClassifiable() (*components.Classifiable, error) {
+	component, err := e.GetComponent(&components.Classifiable{})
+	if err != nil {
+		return nil, err
+	}
+	return component.(*components.Classifiable), nil
+}
+
+func (e *Entity) GetMaintainable() (*components.Maintainable, error) {
+	component, err := e.GetComponent(&components.Maintainable{})
+	if err != nil {
+		return nil, err
+	}
+	return component.(*components.Maintainable), nil
+}
+
+func (e *Entity) GetOperatingExpenses() (*components.OperatingExpenses, error) {
+	component, err := e.GetComponent(&components.OperatingExpenses{})
+	if err != nil {
+		return nil, err
+	}
+	return component.(*components.OperatingExpenses), nil
+}
+
+func (e *Entity) GetValuation() (*components.Valuation, error) {
+	component, err := e.GetComponent(&components.Valuation{})
+	if err != nil {
+		return nil, err
+	}
+	return component.(*components.Valuation), nil
+}
+
+func (e *Entity) GetBankAccount() (*components.BankAccount, error) {
+	component, err := e.GetComponent(&components.BankAccount{})
+	if err != nil {
+		return nil, err
+	}
+	return component.(*components.BankAccount), nil
+}
+
+func (e *Entity) GetSolvency() (*components.Solvency, error) {
+	component, err := e.GetComponent(&components.Solvency{})
+	if err != nil {
+		return nil, err
+	}
+	return component.(*components.Solvency), nil
+}
+
+func (e *Entity) GetDesirability() (*components.Desirability, error) {
+	component, err := e.GetComponent(&components.Desirability{})
+	if err != nil {
+		return nil, err
+	}
+	return component.(*components.Desirability), nil
+}
+
+func (e *Entity) GetInformation() (*components.Information, error) {
+	component, err := e.GetComponent(&components.Information{})
+	if err != nil {
+		return nil, err
+	}
+	return component.(*components.Information), nil
+}
+
+func (e *Entity) GetAuction() (*components.Auction, error) {
+	component, err := e.GetComponent(&components.Auction{})
+	if err != nil {
+		return nil, err
+	}
+	return component.(*components.Auction), nil
+}
+
+func (e *Entity) GetLedger() (*components.Ledger, error) {
+	component, err := e.GetComponent(&components.Ledger{})
+	if err != nil {
+		return nil, err
+	}
+	return component.(*components.Ledger), nil
+}
+
+func (e *Entity) GetFinancialStatements() (*components.FinancialStatements, error) {
+	component, err := e.GetComponent(&components.FinancialStatements{})
+	if err != nil {
+		return nil, err
+	}
+	return component.(*components.FinancialStatements), nil
+}
+
+// PostTransaction adjusts the entity's funds by amount and records the change in its ledger.
+// Positive amounts are money received, negative amounts are money spent.
+func (e *Entity) PostTransaction(date time.Time, category components.TransactionCategory, amount float64, propertyID int, description string) {
+	funds, err := e.GetFunds()
+	if err != nil {
+		return
+	}
+	funds.Amount += amount
+
+	if ledger, err := e.GetLedger(); err == nil {
+		ledger.Transactions = append(ledger.Transactions, components.Transaction{
+			Date:        date,
+			Category:    category,
+			Amount:      amount,
+			PropertyID:  propertyID,
+			Description: description,
+		})
+	}
+}
+
 func (e *Entity) AddFunds(funds *components.Funds) error {
 	return e.AddComponent(funds)
 }
@@ -93,3 +229,32 @@ func (w *World) GetAllPropertiesMap() map[int]*Entity {
 	}
 	return entities
 }
+
+// GetNeighborhood returns the neighborhood entity for the given group, or nil if there is none.
+func (w *World) GetNeighborhood(groupID int) *Entity {
+	for _, entity := range w.Entities {
+		if entity.Type != "Neighborhood" {
+			continue
+		}
+		if groupable, err := entity.GetGroupable(); err == nil && groupable.GroupID == groupID {
+			return entity
+		}
+	}
+	return nil
+}
+
+func (e *Entity) GetMarketIndex() (*components.MarketIndex, error) {
+	component, err := e.GetComponent(&components.MarketIndex{})
+	if err != nil {
+		return nil, err
+	}
+	return component.(*components.MarketIndex), nil
+}
+
+func (e *Entity) GetRecurringPayment() (*components.RecurringPayment, error) {
+	component, err := e.GetComponent(&components.RecurringPayment{})
+	if err != nil {
+		return nil, err
+	}
+	return component.(*components.RecurringPayment), nil
+}
@@ -82,10 +82,10 @@ func (w *World) AddEntity(entity *Entity) {
 
 	// Update indexing for ownership and group
 	if entity.Type == "Property" {
-		if ownable, err := entity.GetOwnable(); err != nil && ownable.Owned {
+		if ownable, err := entity.GetOwnable(); err == nil && ownable.Owned {
 			w.OwnedPropertiesIndex[ownable.OwnerID] = append(w.OwnedPropertiesIndex[ownable.OwnerID], entity.ID)
 		}
-		if groupable, err := entity.GetGroupable(); err != nil {
+		if groupable, err := entity.GetGroupable(); err == nil {
 			w.GroupPropertiesIndex[groupable.GroupID] = append(w.GroupPropertiesIndex[groupable.GroupID], entity.ID)
 		}
 	}
@@ -190,6 +190,46 @@ func (w *World) GetCurrentGameTime() (*components.GameTime, error) {
 	return nil, errors.New("GameTime component not found in the world")
 }
 
+func (w *World) GetEconomy() (*components.Economy, error) {
+	for _, entity := range w.QueryByComponent("Economy") {
+		component, err := entity.GetComponent(&components.Economy{})
+		if err == nil {
+			return component.(*components.Economy), nil
+		}
+	}
+	return nil, errors.New("Economy component not found in the world")
+}
+
+func (w *World) GetCentralBank() (*components.CentralBank, error) {
+	for _, entity := range w.QueryByComponent("CentralBank") {
+		component, err := entity.GetComponent(&components.CentralBank{})
+		if err == nil {
+			return component.(*components.CentralBank), nil
+		}
+	}
+	return nil, errors.New("CentralBank component not found in the world")
+}
+
+func (w *World) GetInflation() (*components.Inflation, error) {
+	for _, entity := range w.QueryByComponent("Inflation") {
+		component, err := entity.GetComponent(&components.Inflation{})
+		if err == nil {
+			return component.(*components.Inflation), nil
+		}
+	}
+	return nil, errors.New("Inflation component not found in the world")
+}
+
+func (w *World) GetAuctionHouse() (*components.AuctionHouse, error) {
+	for _, entity := range w.QueryByComponent("AuctionHouse") {
+		component, err := entity.GetComponent(&components.AuctionHouse{})
+		if err == nil {
+			return component.(*components.AuctionHouse), nil
+		}
+	}
+	return nil, errors.New("AuctionHouse component not found in the world")
+}
+
 func (w *World) ApplyUpgradeToProperty(property *Entity, upgrade *components.Upgrade) error {
 	upgradable, err := property.GetUpgradable()
 	if err != nil {
@@ -249,3 +289,37 @@ func (w *World) GetEntitiesInGroup(groupID int) []*Entity {
 
 	return entities
 }
+
+func (w *World) AddLoanToProperty(property *Entity, loan *components.Loan) error {
+	if err := property.AddComponent(loan); err != nil {
+		return err
+	}
+	w.AddComponentToIndex(property, loan)
+	return nil
+}
+
+func (w *World) RemoveLoanFromProperty(property *Entity) {
+	loan, err := property.GetLoan()
+	if err != nil {
+		return
+	}
+	w.RemoveComponentFromIndex(property, loan)
+	property.RemoveComponent(loan)
+}
+
+func (w *World) AddAuctionToProperty(property *Entity, auction *components.Auction) error {
+	if err := property.AddComponent(auction); err != nil {
+		return err
+	}
+	w.AddComponentToIndex(property, auction)
+	return nil
+}
+
+func (w *World) RemoveAuctionFromProperty(property *Entity) {
+	auction, err := property.GetAuction()
+	if err != nil {
+		return
+	}
+	w.RemoveComponentFromIndex(property, auction)
+	property.RemoveComponent(auction)
+}
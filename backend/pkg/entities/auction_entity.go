@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"math"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/** Creates the auction house entity in the game.
+ * An auction house entity has the following components:
+ * AuctionHouse: How often listing auctions are scheduled and the results of past auctions.
+ */
+func CreateAuctionHouse(
+	currentDate time.Time,
+) *ecs.Entity {
+	auctionHouse := ecs.NewEntity("AuctionHouse")
+
+	auctionHouse.AddComponent(&components.AuctionHouse{
+		MonthlyListingChance: 0.25,
+		LastScheduled:        currentDate,
+		Results:              []components.AuctionResult{},
+	})
+
+	return auctionHouse
+}
+
+/** Creates an auction component for a property.
+ * Bidding opens after the notice period and the reserve price is a percentage of the market value,
+ * depending on why the property is being auctioned.
+ */
+func CreateAuction(
+	reason components.AuctionReason,
+	marketValue float64,
+	scheduledDate time.Time,
+) *components.Auction {
+	reservePercentage := components.ListingReservePercentage
+	if reason == components.ForeclosureAuction {
+		reservePercentage = components.ForeclosureReservePercentage
+	}
+	reservePrice := math.Round(marketValue * reservePercentage / 100)
+	startDate := scheduledDate.AddDate(0, 0, components.AuctionNoticeDays)
+
+	return &components.Auction{
+		Reason:       reason,
+		Status:       components.AuctionScheduled,
+		StartDate:    startDate,
+		EndDate:      startDate.AddDate(0, 0, components.AuctionBiddingDays),
+		ReservePrice: reservePrice,
+		BidIncrement: math.Round(reservePrice * components.BidIncrementPercentage / 100),
+		Bids:         []components.Bid{},
+	}
+}
@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/** Creates the central bank entity in the game.
+ * A central bank entity has the following components:
+ * CentralBank: The policy rate and the spreads used for savings and credit lines.
+ */
+func CreateCentralBank(
+	currentDate time.Time,
+) *ecs.Entity {
+	bank := ecs.NewEntity("CentralBank")
+
+	bank.AddComponent(&components.CentralBank{
+		Rate: components.InitialPolicyRate,
+		PhaseRates: map[components.EconomicPhase]float64{
+			components.Expansion: 0.035,
+			components.Peak:      0.05,
+			components.Recession: 0.01,
+			components.Recovery:  0.02,
+		},
+		SavingsSpread:    0.01,
+		CreditLineSpread: 0.04,
+		MaxLoanToValue:   50,
+		LastUpdated:      currentDate,
+	})
+
+	return bank
+}
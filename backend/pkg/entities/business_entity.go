@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+)
+
+/** Creates the business a commercial tenant runs from a property of the given subtype.
+ * Its category sets how it trades and its quality scales its sales.
+ */
+func CreateBusiness(
+	subtype components.PropertySubtype,
+	quality float64,
+	openingDate time.Time,
+) *components.Business {
+	category, ok := components.SubtypeBusinessCategories[subtype]
+	if !ok {
+		category = components.RetailBusiness
+	}
+
+	return &components.Business{
+		Category:                category,
+		Subtype:                 subtype,
+		Quality:                 quality,
+		LastMonthSales:          0,
+		LastMonthPercentageRent: 0,
+		PercentageRentDue:       0,
+		MonthsStruggling:        0,
+		LastUpdated:             openingDate,
+	}
+}
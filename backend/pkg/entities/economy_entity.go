@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/** Creates the economy entity in the game.
+ * An economy entity has the following components:
+ * Economy: The phase of the economic cycle and the rent, vacancy and price conditions it drives.
+ */
+func CreateEconomy(
+	currentDate time.Time,
+) *ecs.Entity {
+	economy := ecs.NewEntity("Economy")
+
+	economy.AddComponent(&components.Economy{
+		Phase:          components.Expansion,
+		MonthsInPhase:  0,
+		RentMultiplier: 1.0,
+		VacancyRate:    5.0,
+		PriceIndex:     100.0,
+		LastUpdated:    currentDate,
+		Phases:         DefaultEconomicPhases(),
+	})
+
+	return economy
+}
+
+func DefaultEconomicPhases() map[components.EconomicPhase]components.PhaseSettings {
+	return map[components.EconomicPhase]components.PhaseSettings{
+		components.Expansion: {
+			RentMultiplier:     1.05,
+			VacancyRate:        4,
+			MonthlyPriceGrowth: 0.5,
+			MinMonths:          24,
+			MaxMonths:          72,
+			TransitionChance:   0.05,
+			Next:               components.Peak,
+		},
+		components.Peak: {
+			RentMultiplier:     1.10,
+			VacancyRate:        3,
+			MonthlyPriceGrowth: 0.2,
+			MinMonths:          6,
+			MaxMonths:          18,
+			TransitionChance:   0.15,
+			Next:               components.Recession,
+		},
+		components.Recession: {
+			RentMultiplier:     0.90,
+			VacancyRate:        10,
+			MonthlyPriceGrowth: -0.8,
+			MinMonths:          9,
+			MaxMonths:          24,
+			TransitionChance:   0.10,
+			Next:               components.Recovery,
+		},
+		components.Recovery: {
+			RentMultiplier:     0.97,
+			VacancyRate:        7,
+			MonthlyPriceGrowth: 0.3,
+			MinMonths:          12,
+			MaxMonths:          36,
+			TransitionChance:   0.08,
+			Next:               components.Expansion,
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/** Creates the inflation entity in the game.
+ * An inflation entity has the following components:
+ * Inflation: The yearly inflation rate and the cumulative index applied to rents, costs and prices.
+ */
+func CreateInflation(
+	currentDate time.Time,
+) *ecs.Entity {
+	inflation := ecs.NewEntity("Inflation")
+
+	inflation.AddComponent(&components.Inflation{
+		AnnualRate: 0.025,
+		PhaseRates: map[components.EconomicPhase]float64{
+			components.Expansion: 0.025,
+			components.Peak:      0.04,
+			components.Recession: 0.01,
+			components.Recovery:  0.02,
+		},
+		Index:       1.0,
+		LastUpdated: time.Date(currentDate.Year(), 1, 1, 0, 0, 0, 0, currentDate.Location()),
+	})
+
+	return inflation
+}
@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+)
+
+/** Creates a lease component for a tenant of a property of the given type.
+ * The escalation clause comes from the standard lease terms for the property type.
+ */
+func CreateLease(
+	propertyType components.PropertyType,
+	monthlyRent float64,
+	startDate time.Time,
+	termMonths int,
+) *components.Lease {
+	terms := components.StandardLeaseTerms[propertyType]
+
+	return &components.Lease{
+		Type:                       propertyType,
+		StartDate:                  startDate,
+		EndDate:                    startDate.AddDate(0, termMonths, 0),
+		TermMonths:                 termMonths,
+		MonthlyRent:                monthlyRent,
+		AnnualEscalationPercentage: terms.AnnualEscalationPercentage,
+		Renewals:                   0,
+	}
+}
@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+)
+
+/** Creates a loan component for financing a property.
+ * The first payment is due on the first day of the month following the start date.
+ */
+func CreateLoan(
+	borrowerID int,
+	principal float64,
+	annualRate float64,
+	termMonths int,
+	startDate time.Time,
+) *components.Loan {
+	firstOfMonth := time.Date(startDate.Year(), startDate.Month(), 1, 0, 0, 0, 0, startDate.Location())
+
+	return &components.Loan{
+		BorrowerID:           borrowerID,
+		Principal:            principal,
+		OutstandingPrincipal: principal,
+		AnnualRate:           annualRate,
+		TermMonths:           termMonths,
+		MonthlyPayment:       components.AmortizedPayment(principal, annualRate, termMonths),
+		StartDate:            startDate,
+		NextPaymentDate:      firstOfMonth.AddDate(0, 1, 0),
+		PaymentsMade:         0,
+		TotalInterestPaid:    0,
+	}
+}
@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/** Creates a neighborhood entity in the game.
+ * A neighborhood entity has the following components:
+ * Information: The name and description of the neighborhood.
+ * Groupable: The group ID shared by every property in the neighborhood.
+ * TaxDistrict: The property tax millage rate, due date and late penalty.
+ * Desirability: How attractive the neighborhood is to tenants.
+ * MarketIndex: The neighborhood's monthly price and rent index and its local appreciation.
+ */
+func CreateNeighborhood(
+	name string,
+	description string,
+	groupID int,
+	millageRate float64,
+	desirability float64,
+) *ecs.Entity {
+	neighborhood := ecs.NewEntity("Neighborhood")
+
+	neighborhood.AddComponent(&components.Information{Description: description, Name: name})
+	neighborhood.AddComponent(&components.Groupable{GroupID: groupID})
+	neighborhood.AddComponent(&components.TaxDistrict{
+		MillageRate:     millageRate,
+		DueMonth:        time.April,
+		DueDay:          30,
+		LatePenaltyRate: 0.015,
+	})
+	neighborhood.AddComponent(&components.Desirability{Value: desirability})
+	neighborhood.AddComponent(&components.MarketIndex{
+		PriceIndex:  100,
+		RentIndex:   100,
+		LocalFactor: 1,
+		Volatility:  0.4,
+	})
+
+	return neighborhood
+}
@@ -9,6 +9,10 @@ import (
  * A player entity has the following components:
  * Nameable: The name of the player.
  * Funds: The current funds available to the player.
+ * Ledger: Every transaction that changed the player's funds.
+ * FinancialStatements: The player's closed monthly financial statements.
+ * BankAccount: The player's savings and credit line balances.
+ * Solvency: Whether the player is solvent, warned about negative funds or bankrupt.
  */
 func CreatePlayer(
 	name string,
@@ -18,6 +22,10 @@ func CreatePlayer(
 
 	player.AddComponent(&components.Information{Name: name})
 	player.AddComponent(&components.Funds{Amount: initialFunds})
+	player.AddComponent(&components.Ledger{Transactions: []components.Transaction{}})
+	player.AddComponent(&components.FinancialStatements{Monthly: []*components.FinancialStatement{}})
+	player.AddComponent(&components.BankAccount{})
+	player.AddComponent(&components.Solvency{Status: components.Solvent, Notices: []string{}})
 
 	return player
 }
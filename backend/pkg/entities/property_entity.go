@@ -13,11 +13,15 @@ import (
  * Addressable: The address of the property.
  * Describable: The description of the property.
  * Classifiable: The type and subtype of the property.
- * Rentable: The base rent and rent boost of the property.
+ * Rentable: The base rent, rent boost and units of the property; a single unit renting for the base rent.
  * Purchasable: The cost to purchase the property.
  * Ownable: The owner of the property.
  * Upgradable: The possible upgrades and applied upgrades of the property.
  * Groupable: The group ID of the property.
+ * Taxable: The yearly property tax assessment and bills of the property.
+ * Maintainable: The condition, outstanding maintenance and damage of the property.
+ * OperatingExpenses: The expense rates charged against the property's rent, by type and subtype.
+ * Valuation: The current market value of the property.
  */
 func CreateProperty(
 	name string,
@@ -33,15 +37,53 @@ func CreateProperty(
 
 	property.AddComponent(&components.Information{Description: description, Name: name, Address: address})
 	property.AddComponent(&components.Classifiable{Type: propertyType, Subtype: subtype})
-	property.AddComponent(&components.Rentable{BaseRent: baseRent, RentBoost: 0, LastRentCollectionDate: time.Time{}})
+	property.AddComponent(&components.Rentable{BaseRent: baseRent, RentBoost: 0, LastRentCollectionDate: time.Time{}, Units: CreateUnits(1, baseRent)})
 	property.AddComponent(&components.Purchaseable{Cost: price, PurchaseDate: time.Time{}})
 	property.AddComponent(&components.Ownable{OwnerID: 0, Owned: false})
 	property.AddComponent(&components.Upgradable{PossibleUpgrades: map[string][]*components.Upgrade{}, AppliedUpgrades: []*components.Upgrade{}})
 	property.AddComponent(&components.Groupable{GroupID: groupID})
+	property.AddComponent(&components.Taxable{AssessedValue: price, LastAssessmentYear: 0, Bills: []*components.TaxBill{}})
+	property.AddComponent(&components.Maintainable{Condition: 100, MaintenanceDue: 0, DamageEvents: []string{}})
+	property.AddComponent(&components.OperatingExpenses{Rates: components.OperatingExpenseRatesFor(propertyType, subtype)})
+	property.AddComponent(&components.Valuation{BaseValue: price, MarketValue: price, Factors: map[string]float64{}})
 
 	return property
 }
 
+/** Creates a property divided into units, such as an apartment building, multifamily home, duplex or condo block.
+ * Each unit is let separately and rents for the unit rent, and the property's base rent is the sum over its units.
+ */
+func CreateMultiUnitProperty(
+	name string,
+	address string,
+	description string,
+	propertyType components.PropertyType,
+	subtype components.PropertySubtype,
+	unitCount int,
+	unitRent float64,
+	price float64,
+	groupID int,
+) *ecs.Entity {
+	property := CreateProperty(name, address, description, propertyType, subtype, unitRent*float64(unitCount), price, groupID)
+	rentable, _ := property.GetRentable()
+	rentable.Units = CreateUnits(unitCount, unitRent)
+	return property
+}
+
+/** Creates vacant units numbered from 1, each with the same base rent. */
+func CreateUnits(count int, baseRent float64) []*components.Unit {
+	units := []*components.Unit{}
+	for number := 1; number <= count; number++ {
+		units = append(units, &components.Unit{
+			Number:     number,
+			BaseRent:   baseRent,
+			AskingRent: 0,
+			Tenant:     nil,
+		})
+	}
+	return units
+}
+
 func AddUpgradesToProperty(property *ecs.Entity, upgradePaths map[string][]*components.Upgrade) {
 	upgradable, err := property.GetUpgradable()
 	if err != nil {
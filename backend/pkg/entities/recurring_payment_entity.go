@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/** Creates a recurring payment entity in the game.
+ * A recurring payment entity has the following components:
+ * RecurringPayment: Who pays whom, how much, how often and what happens when a payment fails.
+ * The first payment is due on the start date.
+ */
+func CreateRecurringPayment(
+	description string,
+	payerID int,
+	payeeID int,
+	propertyID int,
+	category components.TransactionCategory,
+	amount float64,
+	frequency components.PaymentFrequency,
+	startDate time.Time,
+	endDate time.Time,
+	failurePolicy components.PaymentFailurePolicy,
+) *ecs.Entity {
+	recurringPayment := ecs.NewEntity("RecurringPayment")
+
+	recurringPayment.AddComponent(&components.RecurringPayment{
+		Description:   description,
+		PayerID:       payerID,
+		PayeeID:       payeeID,
+		PropertyID:    propertyID,
+		Category:      category,
+		Amount:        amount,
+		Frequency:     frequency,
+		StartDate:     startDate,
+		EndDate:       endDate,
+		NextDueDate:   startDate,
+		FailurePolicy: failurePolicy,
+	})
+
+	return recurringPayment
+}
+
+/** Creates the recurring payment that pays off a mortgage.
+ * The borrower pays the loan's monthly payment from its next payment date until the loan is paid off.
+ * Payments are always debited, even if the borrower cannot afford them.
+ */
+func CreateMortgagePayment(propertyID int, loan *components.Loan) *ecs.Entity {
+	mortgagePayment := CreateRecurringPayment(
+		"Mortgage payment",
+		loan.BorrowerID,
+		components.ExternalParty,
+		propertyID,
+		components.LoanPrincipal,
+		loan.MonthlyPayment,
+		components.MonthlyFrequency,
+		loan.NextPaymentDate,
+		time.Time{},
+		components.DebitOnFailure,
+	)
+	payment, _ := mortgagePayment.GetRecurringPayment()
+	payment.Mortgage = true
+
+	return mortgagePayment
+}
@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+)
+
+/** Creates an application from a prospective tenant to rent a vacant unit at its advertised rent.
+ * The applicant's credit risk stays hidden until the owners pay to screen them.
+ */
+func CreateTenantApplication(
+	id int,
+	appliedDate time.Time,
+	monthlyIncome float64,
+	rent float64,
+	desiredRent float64,
+	leaseMonths int,
+	creditRisk float64,
+) *components.TenantApplication {
+	return &components.TenantApplication{
+		ID:            id,
+		AppliedDate:   appliedDate,
+		MonthlyIncome: monthlyIncome,
+		Rent:          rent,
+		DesiredRent:   desiredRent,
+		LeaseMonths:   leaseMonths,
+		Screened:      false,
+		CreditScore:   0,
+		CreditRisk:    creditRisk,
+	}
+}
@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+)
+
+/** Creates a tenant component for a tenant moving into a unit of a property.
+ * The tenant signs a new lease of the length they asked for at the rent they move in at,
+ * with the rest of the terms standard for the property type.
+ * The security deposit is a multiple of that rent.
+ * Tenants on leases that can't be broken never move out before the lease ends.
+ */
+func CreateTenant(
+	propertyType components.PropertyType,
+	desiredRent float64,
+	monthlyRent float64,
+	leaseMonths int,
+	paymentRisk float64,
+	moveInDate time.Time,
+) *components.Tenant {
+	terms := components.StandardLeaseTerms[propertyType]
+	moveOutChance := 0.0
+	if terms.Breakable {
+		moveOutChance = components.MonthlyMoveOutChance
+	}
+
+	return &components.Tenant{
+		Happiness:        components.Happiness{Value: 100},
+		RentDue:          0,
+		LateFeesDue:      0,
+		MonthsWithoutPay: 0,
+		MoveOutChance:    moveOutChance,
+		DesiredRent:      desiredRent,
+		PaymentRisk:      paymentRisk,
+		SecurityDeposit:  monthlyRent * components.SecurityDepositMonths,
+		MoveInDate:       moveInDate,
+		LastMoveOutRoll:  moveInDate,
+		Lease:            CreateLease(propertyType, monthlyRent, moveInDate, leaseMonths),
+	}
+}
@@ -21,6 +21,12 @@ func InitializeGame() *ecs.World {
 	world.AddEntity(playerEntity)
 
 	initializeProperties(world)
+
+	world.AddEntity(entities.CreateEconomy(initialDate))
+	world.AddEntity(entities.CreateCentralBank(initialDate))
+	world.AddEntity(entities.CreateInflation(initialDate))
+	world.AddEntity(entities.CreateAuctionHouse(initialDate))
+
 	initializeSystems(world)
 
 	return world
@@ -51,10 +57,26 @@ func initializeProperties(world *ecs.World) {
 	for _, property := range allProperties {
 		world.AddEntity(property)
 	}
+
+	world.AddEntity(neighborhoods.GetCedarGroveNeighborhood())
 }
 
 func initializeSystems(world *ecs.World) {
+	world.AddSystem(&systems.EconomySystem{})
+	world.AddSystem(&systems.InflationSystem{})
+	world.AddSystem(&systems.MaintenanceSystem{})
+	world.AddSystem(&systems.ValuationSystem{})
+	world.AddSystem(&systems.MarketIndexSystem{})
+	world.AddSystem(&systems.HappinessSystem{})
+	world.AddSystem(&systems.BusinessSystem{})
 	world.AddSystem(&systems.RentCollectionSystem{})
+	world.AddSystem(&systems.TenantSystem{})
+	world.AddSystem(&systems.RecurringPaymentSystem{})
+	world.AddSystem(&systems.PropertyTaxSystem{})
+	world.AddSystem(&systems.BankSystem{})
+	world.AddSystem(&systems.BankruptcySystem{})
+	world.AddSystem(&systems.AuctionSystem{})
+	world.AddSystem(&systems.FinancialStatementSystem{})
 	world.AddSystem(&systems.PropertyManagementSystem{})
 	world.AddSystem(&systems.TimeSystem{})
 }
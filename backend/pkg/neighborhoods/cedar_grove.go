@@ -7,6 +7,18 @@ import (
 	"github.com/markbmullins/city-developer/pkg/entities"
 )
 
+const CedarGroveGroupID = 4
+
+func GetCedarGroveNeighborhood() *ecs.Entity {
+	return entities.CreateNeighborhood(
+		"Cedar Grove",
+		"A quiet suburban neighborhood of family homes, apartments and local shops.",
+		CedarGroveGroupID,
+		11.5,
+		55,
+	)
+}
+
 func GetCedarGroveProperties() []*ecs.Entity {
 	return append(CedarResidential, CedarCommercial...)
 }
@@ -31,7 +43,7 @@ var CedarResidential = []*ecs.Entity{
 		components.SingleFamily,
 		1800.0,
 		300000.0,
-		4,
+		CedarGroveGroupID,
 	),
 	entities.CreateProperty(
 		"Sunnybrook Townhome",
@@ -41,37 +53,40 @@ var CedarResidential = []*ecs.Entity{
 		components.Townhome,
 		2200.0,
 		350000.0,
-		4,
+		CedarGroveGroupID,
 	),
-	entities.CreateProperty(
+	entities.CreateMultiUnitProperty(
 		"Oakwood Apartments",
 		"303 Oakwood Road, Cedar Grove",
 		"Modern apartments with access to shared recreational facilities and secure parking.",
 		components.Residential,
 		components.Apartment,
+		8,
 		1500.0,
-		260000.0,
-		4,
+		2080000.0,
+		CedarGroveGroupID,
 	),
-	entities.CreateProperty(
+	entities.CreateMultiUnitProperty(
 		"Cedar Grove Condos",
 		"404 Cedar Boulevard, Cedar Grove",
 		"Condominiums with private balconies and state-of-the-art home automation systems.",
 		components.Residential,
 		components.Condo,
+		6,
 		2000.0,
-		400000.0,
-		4,
+		2400000.0,
+		CedarGroveGroupID,
 	),
-	entities.CreateProperty(
+	entities.CreateMultiUnitProperty(
 		"Cedar Grove Estates",
 		"505 Cedar Lane, Cedar Grove",
 		"Spacious multifamily residences with modern amenities and landscaped gardens.",
 		components.Residential,
 		components.Multifamily,
-		1900.0,
-		380000.0,
 		4,
+		1900.0,
+		1520000.0,
+		CedarGroveGroupID,
 	),
 	entities.CreateProperty(
 		"Cedar Grove Villas",
@@ -81,47 +96,51 @@ var CedarResidential = []*ecs.Entity{
 		components.SingleFamily,
 		2200.0,
 		420000.0,
-		4,
+		CedarGroveGroupID,
 	),
-	entities.CreateProperty(
+	entities.CreateMultiUnitProperty(
 		"Maplewood Condos",
 		"707 Maplewood Street, Cedar Grove",
 		"Condominiums with smart home integrations and access to communal lounges.",
 		components.Residential,
 		components.Condo,
+		6,
 		2100.0,
-		400000.0,
-		4,
+		2520000.0,
+		CedarGroveGroupID,
 	),
-	entities.CreateProperty(
+	entities.CreateMultiUnitProperty(
 		"Sunnybrook Apartments",
 		"808 Sunnybrook Road, Cedar Grove",
 		"Apartments with modern designs and access to recreational facilities.",
 		components.Residential,
 		components.Apartment,
+		10,
 		1700.0,
-		340000.0,
-		4,
+		3400000.0,
+		CedarGroveGroupID,
 	),
-	entities.CreateProperty(
+	entities.CreateMultiUnitProperty(
 		"Cedar Grove Flats",
 		"909 Cedar Circle, Cedar Grove",
 		"Modern flats with integrated smart systems and community amenities.",
 		components.Residential,
 		components.Apartment,
+		8,
 		1600.0,
-		330000.0,
-		4,
+		2640000.0,
+		CedarGroveGroupID,
 	),
-	entities.CreateProperty(
+	entities.CreateMultiUnitProperty(
 		"Oakridge Apartments",
 		"1001 Oakridge Road, Cedar Grove",
 		"Spacious apartments with eco-friendly features and access to green spaces.",
 		components.Residential,
 		components.Apartment,
+		8,
 		1500.0,
-		260000.0,
-		4,
+		2080000.0,
+		CedarGroveGroupID,
 	),
 }
 
@@ -135,7 +154,7 @@ var CedarCommercial = []*ecs.Entity{
 		components.Cafe,
 		4000.0,
 		900000.0,
-		4,
+		CedarGroveGroupID,
 	),
 	entities.CreateProperty(
 		"Suburban Shoppe",
@@ -145,7 +164,7 @@ var CedarCommercial = []*ecs.Entity{
 		components.FurnitureStore,
 		5000.0,
 		1100000.0,
-		4,
+		CedarGroveGroupID,
 	),
 	entities.CreateProperty(
 		"Cedar Gym",
@@ -155,7 +174,7 @@ var CedarCommercial = []*ecs.Entity{
 		components.Gym,
 		6500.0,
 		1400000.0,
-		4,
+		CedarGroveGroupID,
 	),
 	entities.CreateProperty(
 		"Playtime Arcade",
@@ -165,7 +184,7 @@ var CedarCommercial = []*ecs.Entity{
 		components.Arcade,
 		8500.0,
 		1750000.0,
-		4,
+		CedarGroveGroupID,
 	),
 	entities.CreateProperty(
 		"Tech Mart",
@@ -175,7 +194,7 @@ var CedarCommercial = []*ecs.Entity{
 		components.ElectronicsStore,
 		7500.0,
 		1600000.0,
-		4,
+		CedarGroveGroupID,
 	),
 	entities.CreateProperty(
 		"Cedar Pharmacy",
@@ -185,7 +204,7 @@ var CedarCommercial = []*ecs.Entity{
 		components.Clinic,
 		8500.0,
 		1750000.0,
-		4,
+		CedarGroveGroupID,
 	),
 	entities.CreateProperty(
 		"Simple Salon",
@@ -195,7 +214,7 @@ var CedarCommercial = []*ecs.Entity{
 		components.Salon,
 		3200.0,
 		750000.0,
-		4,
+		CedarGroveGroupID,
 	),
 	entities.CreateProperty(
 		"Affordable Arcade",
@@ -205,7 +224,7 @@ var CedarCommercial = []*ecs.Entity{
 		components.Arcade,
 		8500.0,
 		1750000.0,
-		4,
+		CedarGroveGroupID,
 	),
 	entities.CreateProperty(
 		"Cedar Bakery",
@@ -215,7 +234,7 @@ var CedarCommercial = []*ecs.Entity{
 		components.Bakery,
 		7500.0,
 		1800000.0,
-		4,
+		CedarGroveGroupID,
 	),
 	entities.CreateProperty(
 		"Playtime Arcade",
@@ -225,7 +244,7 @@ var CedarCommercial = []*ecs.Entity{
 		components.Arcade,
 		8500.0,
 		1750000.0,
-		4,
+		CedarGroveGroupID,
 	),
 }
 
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/markbmullins/city-developer/pkg/ecs"
+	"github.com/markbmullins/city-developer/pkg/systems"
+	"github.com/markbmullins/city-developer/pkg/utils"
+)
+
+const (
+	defaultForecastMonths = 12
+	maxForecastMonths     = 120
+)
+
+// handleForecast returns projected monthly cash flows per player.
+// Query parameters:
+// - player_id: the player to forecast (optional, defaults to every player)
+// - months: how many months to project (optional, defaults to 12, at most 120)
+func handleForecast(world *ecs.World, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendResponse(w, http.StatusMethodNotAllowed, "Invalid request method", nil)
+		return
+	}
+
+	months := defaultForecastMonths
+	if value := r.URL.Query().Get("months"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > maxForecastMonths {
+			utils.SendResponse(w, http.StatusBadRequest, "Invalid months", nil)
+			return
+		}
+		months = parsed
+	}
+
+	players := world.Players
+	if value := r.URL.Query().Get("player_id"); value != "" {
+		playerID, err := strconv.Atoi(value)
+		if err != nil {
+			utils.SendResponse(w, http.StatusBadRequest, "Invalid player_id", nil)
+			return
+		}
+		player := world.GetEntity(playerID)
+		if player == nil || player.Type != "Player" {
+			utils.SendResponse(w, http.StatusNotFound, "Player not found", nil)
+			return
+		}
+		players = []*ecs.Entity{player}
+	}
+
+	forecasts := []*systems.CashFlowForecast{}
+	for _, player := range players {
+		forecasts = append(forecasts, systems.ForecastCashFlow(world, player, months))
+	}
+
+	utils.SendResponse(w, http.StatusOK, "Cash flow forecast generated successfully", forecasts)
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+	"github.com/markbmullins/city-developer/pkg/utils"
+)
+
+type NeighborhoodMarket struct {
+	GroupID     int                           `json:"group_id"`
+	Name        string                        `json:"name"`
+	PriceIndex  float64                       `json:"price_index"`
+	RentIndex   float64                       `json:"rent_index"`
+	LocalFactor float64                       `json:"local_factor"`
+	History     []components.MarketIndexPoint `json:"history"`
+}
+
+// handleMarket returns the price and rent index of each neighborhood with its monthly history.
+// Query parameters:
+// - group_id: only this neighborhood (optional)
+// - from: only readings on or after this date, as YYYY-MM-DD (optional)
+// - to: only readings on or before this date, as YYYY-MM-DD (optional)
+func handleMarket(world *ecs.World, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendResponse(w, http.StatusMethodNotAllowed, "Invalid request method", nil)
+		return
+	}
+	query := r.URL.Query()
+
+	groupID := 0
+	if value := query.Get("group_id"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			utils.SendResponse(w, http.StatusBadRequest, "Invalid group_id", nil)
+			return
+		}
+		groupID = parsed
+	}
+
+	var from, to time.Time
+	if value := query.Get("from"); value != "" {
+		parsed, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			utils.SendResponse(w, http.StatusBadRequest, "Invalid from", nil)
+			return
+		}
+		from = parsed
+	}
+	if value := query.Get("to"); value != "" {
+		parsed, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			utils.SendResponse(w, http.StatusBadRequest, "Invalid to", nil)
+			return
+		}
+		to = parsed
+	}
+
+	markets := []*NeighborhoodMarket{}
+	for _, neighborhood := range world.QueryByComponent("MarketIndex") {
+		groupable, err := neighborhood.GetGroupable()
+		if err != nil || (groupID != 0 && groupable.GroupID != groupID) {
+			continue
+		}
+		marketIndex, _ := neighborhood.GetMarketIndex()
+
+		market := &NeighborhoodMarket{
+			GroupID:     groupable.GroupID,
+			PriceIndex:  marketIndex.PriceIndex,
+			RentIndex:   marketIndex.RentIndex,
+			LocalFactor: marketIndex.LocalFactor,
+			History:     []components.MarketIndexPoint{},
+		}
+		if information, err := neighborhood.GetInformation(); err == nil {
+			market.Name = information.Name
+		}
+		for _, point := range marketIndex.History {
+			if (!from.IsZero() && point.Date.Before(from)) || (!to.IsZero() && point.Date.After(to)) {
+				continue
+			}
+			market.History = append(market.History, point)
+		}
+		markets = append(markets, market)
+	}
+	if groupID != 0 && len(markets) == 0 {
+		utils.SendResponse(w, http.StatusNotFound, "Neighborhood not found", nil)
+		return
+	}
+	sort.Slice(markets, func(i, j int) bool { return markets[i].GroupID < markets[j].GroupID })
+
+	utils.SendResponse(w, http.StatusOK, "Market indexes retrieved successfully", markets)
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/markbmullins/city-developer/pkg/ecs"
+	"github.com/markbmullins/city-developer/pkg/systems"
+	"github.com/markbmullins/city-developer/pkg/utils"
+)
+
+// Values of the sort query parameter and the metric each one orders properties by
+var propertyMetricSorts = map[string]func(*systems.PropertyMetrics) float64{
+	"cap_rate":            func(m *systems.PropertyMetrics) float64 { return m.CapRate },
+	"cash_on_cash_return": func(m *systems.PropertyMetrics) float64 { return m.CashOnCashReturn },
+	"roi":                 func(m *systems.PropertyMetrics) float64 { return m.ROI },
+	"annual_noi":          func(m *systems.PropertyMetrics) float64 { return m.AnnualNOI },
+	"annual_cash_flow":    func(m *systems.PropertyMetrics) float64 { return m.AnnualCashFlow },
+	"market_value":        func(m *systems.PropertyMetrics) float64 { return m.MarketValue },
+	"payback_years":       func(m *systems.PropertyMetrics) float64 { return *m.PaybackYears },
+}
+
+// handleMetrics returns investment metrics per property and per player portfolio.
+// Query parameters:
+// - player_id: only properties this player owns a share of, and their portfolio (optional)
+// - group_id: only properties in this neighborhood (optional)
+// - type: only "Residential" or "Commercial" properties (optional)
+// - owned: "true" for owned properties only, "false" for properties for sale only (optional)
+// - min_cap_rate: only properties with at least this cap rate, e.g. 0.05 (optional)
+// - sort: cap_rate (default), cash_on_cash_return, roi, annual_noi, annual_cash_flow, market_value or payback_years
+// - order: "desc" (default) or "asc"; properties without a payback period are always listed last
+func handleMetrics(world *ecs.World, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendResponse(w, http.StatusMethodNotAllowed, "Invalid request method", nil)
+		return
+	}
+	query := r.URL.Query()
+
+	players := world.Players
+	playerID := 0
+	if value := query.Get("player_id"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			utils.SendResponse(w, http.StatusBadRequest, "Invalid player_id", nil)
+			return
+		}
+		player := world.GetEntity(parsed)
+		if player == nil || player.Type != "Player" {
+			utils.SendResponse(w, http.StatusNotFound, "Player not found", nil)
+			return
+		}
+		playerID = parsed
+		players = []*ecs.Entity{player}
+	}
+
+	groupID := 0
+	if value := query.Get("group_id"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			utils.SendResponse(w, http.StatusBadRequest, "Invalid group_id", nil)
+			return
+		}
+		groupID = parsed
+	}
+
+	var owned *bool
+	if value := query.Get("owned"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			utils.SendResponse(w, http.StatusBadRequest, "Invalid owned", nil)
+			return
+		}
+		owned = &parsed
+	}
+
+	var minCapRate *float64
+	if value := query.Get("min_cap_rate"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			utils.SendResponse(w, http.StatusBadRequest, "Invalid min_cap_rate", nil)
+			return
+		}
+		minCapRate = &parsed
+	}
+
+	sortKey := query.Get("sort")
+	if sortKey == "" {
+		sortKey = "cap_rate"
+	}
+	metricValue, ok := propertyMetricSorts[sortKey]
+	if !ok {
+		utils.SendResponse(w, http.StatusBadRequest, "Invalid sort", nil)
+		return
+	}
+	order := query.Get("order")
+	if order == "" {
+		order = "desc"
+	}
+	if order != "asc" && order != "desc" {
+		utils.SendResponse(w, http.StatusBadRequest, "Invalid order", nil)
+		return
+	}
+
+	propertyType := query.Get("type")
+	properties := []*systems.PropertyMetrics{}
+	for _, property := range world.GetAllProperties() {
+		metrics := systems.CalculatePropertyMetrics(world, property)
+		if playerID != 0 && metrics.Shareholders[playerID] <= 0 {
+			continue
+		}
+		if groupID != 0 && metrics.GroupID != groupID {
+			continue
+		}
+		if propertyType != "" && metrics.Type != propertyType {
+			continue
+		}
+		if owned != nil && metrics.Owned != *owned {
+			continue
+		}
+		if minCapRate != nil && metrics.CapRate < *minCapRate {
+			continue
+		}
+		properties = append(properties, metrics)
+	}
+
+	sort.Slice(properties, func(i, j int) bool {
+		a, b := properties[i], properties[j]
+		if sortKey == "payback_years" && (a.PaybackYears == nil || b.PaybackYears == nil) {
+			if (a.PaybackYears == nil) != (b.PaybackYears == nil) {
+				return b.PaybackYears == nil
+			}
+			return a.PropertyID < b.PropertyID
+		}
+		if metricValue(a) == metricValue(b) {
+			return a.PropertyID < b.PropertyID
+		}
+		if order == "asc" {
+			return metricValue(a) < metricValue(b)
+		}
+		return metricValue(a) > metricValue(b)
+	})
+
+	portfolios := []*systems.PortfolioMetrics{}
+	for _, player := range players {
+		portfolios = append(portfolios, systems.CalculatePortfolioMetrics(world, player))
+	}
+
+	utils.SendResponse(w, http.StatusOK, "Investment metrics calculated successfully", map[string]interface{}{
+		"properties": properties,
+		"portfolios": portfolios,
+	})
+}
@@ -52,6 +52,36 @@ func StartServer(world *ecs.World) *http.Server {
 		sendPartialWorld(w, world)
 	})
 
+	mux.HandleFunc("/statements", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		handleStatements(world, w, r)
+	})
+
+	mux.HandleFunc("/forecast", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		handleForecast(world, w, r)
+	})
+
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		handleMetrics(world, w, r)
+	})
+
+	mux.HandleFunc("/market", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		handleMarket(world, w, r)
+	})
+
+	mux.HandleFunc("/tenants", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		handleTenants(world, w, r)
+	})
+
 	c := cors.New(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:5173"},
 		AllowedMethods:   []string{"GET", "POST", "OPTIONS"},
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/markbmullins/city-developer/pkg/ecs"
+	"github.com/markbmullins/city-developer/pkg/systems"
+	"github.com/markbmullins/city-developer/pkg/utils"
+)
+
+// handleStatements returns a player's closed financial statements.
+// Query parameters:
+// - player_id: the player to report on (required)
+// - period: "month" (default), "quarter" or "year"
+// - basis: "nominal" (default) or "real" for figures in start of game dollars
+func handleStatements(world *ecs.World, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendResponse(w, http.StatusMethodNotAllowed, "Invalid request method", nil)
+		return
+	}
+
+	playerID, err := strconv.Atoi(r.URL.Query().Get("player_id"))
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest, "Missing or invalid player_id", nil)
+		return
+	}
+
+	period := systems.StatementPeriod(r.URL.Query().Get("period"))
+	if period == "" {
+		period = systems.MonthlyPeriod
+	}
+	if period != systems.MonthlyPeriod && period != systems.QuarterlyPeriod && period != systems.YearlyPeriod {
+		utils.SendResponse(w, http.StatusBadRequest, "Invalid period", nil)
+		return
+	}
+
+	basis := r.URL.Query().Get("basis")
+	if basis != "" && basis != "nominal" && basis != "real" {
+		utils.SendResponse(w, http.StatusBadRequest, "Invalid basis", nil)
+		return
+	}
+
+	player := world.GetEntity(playerID)
+	if player == nil || player.Type != "Player" {
+		utils.SendResponse(w, http.StatusNotFound, "Player not found", nil)
+		return
+	}
+
+	statements, err := player.GetFinancialStatements()
+	if err != nil {
+		utils.SendResponse(w, http.StatusNotFound, "Player has no financial statements", nil)
+		return
+	}
+
+	monthly := statements.Monthly
+	if basis == "real" {
+		monthly = systems.RealStatements(monthly)
+	}
+
+	utils.SendResponse(w, http.StatusOK, "Financial statements retrieved successfully", systems.AggregateStatements(monthly, period))
+}
@@ -0,0 +1,172 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+	"github.com/markbmullins/city-developer/pkg/utils"
+)
+
+type TenantReport struct {
+	PropertyID       int                  `json:"property_id"`
+	Name             string               `json:"name"`
+	OwnerID          int                  `json:"owner_id"`
+	Unit             int                  `json:"unit"`
+	Occupied         bool                 `json:"occupied"`
+	VacantSince      *time.Time           `json:"vacant_since,omitempty"`
+	MoveInDate       *time.Time           `json:"move_in_date,omitempty"`
+	Happiness        float64              `json:"happiness"`
+	HappinessFactors map[string]float64   `json:"happiness_factors"` // Points each factor added to or took from the base of 70
+	MoveOutChance    float64              `json:"move_out_chance"`
+	Arrears          float64              `json:"arrears"`
+	LeaseRent        float64              `json:"lease_rent"`
+	LeaseEndDate     *time.Time           `json:"lease_end_date,omitempty"`
+	LeaseRenewals    int                  `json:"lease_renewals"`
+	Business         *BusinessReport      `json:"business,omitempty"`     // Commercial tenants only
+	Applications     []*ApplicationReport `json:"applications,omitempty"` // Open applications while vacant
+}
+
+type BusinessReport struct {
+	Category                components.BusinessCategory `json:"category"`
+	LastMonthSales          float64                     `json:"last_month_sales"`
+	PercentageRent          float64                     `json:"percentage_rent"`
+	Breakpoint              float64                     `json:"breakpoint"`                 // Monthly sales above which percentage rent is due
+	LastMonthPercentageRent float64                     `json:"last_month_percentage_rent"` // Charged with the month's rent
+	MonthsStruggling        int                         `json:"months_struggling"`          // The business closes after six in a row
+}
+
+type ApplicationReport struct {
+	ID            int       `json:"id"`
+	AppliedDate   time.Time `json:"applied_date"`
+	RespondBy     time.Time `json:"respond_by"` // When the property manager accepts the application if it hasn't been answered
+	MonthlyIncome float64   `json:"monthly_income"`
+	Rent          float64   `json:"rent"`
+	DesiredRent   float64   `json:"desired_rent"`
+	LeaseMonths   int       `json:"lease_months"`
+	Screened      bool      `json:"screened"`
+	CreditScore   int       `json:"credit_score,omitempty"`
+}
+
+// handleTenants returns the tenant of every unit of each owned property with their happiness, lease, arrears
+// and, for commercial tenants, their business,
+// or the open applications of vacant units.
+// Query parameters:
+// - player_id: only properties this player owns a share of (optional)
+// - property_id: only this property (optional)
+func handleTenants(world *ecs.World, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendResponse(w, http.StatusMethodNotAllowed, "Invalid request method", nil)
+		return
+	}
+	query := r.URL.Query()
+
+	playerID := 0
+	if value := query.Get("player_id"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			utils.SendResponse(w, http.StatusBadRequest, "Invalid player_id", nil)
+			return
+		}
+		playerID = parsed
+	}
+	propertyID := 0
+	if value := query.Get("property_id"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			utils.SendResponse(w, http.StatusBadRequest, "Invalid property_id", nil)
+			return
+		}
+		propertyID = parsed
+	}
+
+	gameTime, _ := world.GetCurrentGameTime()
+	reports := []*TenantReport{}
+	for _, property := range world.GetAllProperties() {
+		ownable, err := property.GetOwnable()
+		if err != nil || !ownable.Owned {
+			continue
+		}
+		if (playerID != 0 && ownable.Share(playerID) <= 0) || (propertyID != 0 && property.ID != propertyID) {
+			continue
+		}
+
+		rentable, err := property.GetRentable()
+		if err != nil {
+			continue
+		}
+		name := ""
+		if information, err := property.GetInformation(); err == nil {
+			name = information.Name
+		}
+		for _, unit := range rentable.Units {
+			reports = append(reports, unitTenantReport(property.ID, name, ownable.OwnerID, unit, gameTime.CurrentDate))
+		}
+	}
+	if propertyID != 0 && len(reports) == 0 {
+		utils.SendResponse(w, http.StatusNotFound, "Owned property not found", nil)
+		return
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].PropertyID != reports[j].PropertyID {
+			return reports[i].PropertyID < reports[j].PropertyID
+		}
+		return reports[i].Unit < reports[j].Unit
+	})
+
+	utils.SendResponse(w, http.StatusOK, "Tenants retrieved successfully", reports)
+}
+
+// unitTenantReport describes the unit's tenant, or its vacancy and open applications.
+func unitTenantReport(propertyID int, name string, ownerID int, unit *components.Unit, date time.Time) *TenantReport {
+	report := &TenantReport{PropertyID: propertyID, Name: name, OwnerID: ownerID, Unit: unit.Number, HappinessFactors: map[string]float64{}}
+	if tenant := unit.Tenant; tenant != nil {
+		report.Occupied = true
+		report.MoveInDate = &tenant.MoveInDate
+		report.Happiness = tenant.Happiness.Value
+		if tenant.Happiness.Factors != nil {
+			report.HappinessFactors = tenant.Happiness.Factors
+		}
+		report.MoveOutChance = tenant.MoveOutChance
+		report.Arrears = tenant.Arrears()
+		if tenant.Lease != nil {
+			report.LeaseRent = tenant.Lease.RentOn(date)
+			report.LeaseEndDate = &tenant.Lease.EndDate
+			report.LeaseRenewals = tenant.Lease.Renewals
+		}
+		if business := tenant.Business; business != nil {
+			report.Business = &BusinessReport{
+				Category:                business.Category,
+				LastMonthSales:          business.LastMonthSales,
+				LastMonthPercentageRent: business.LastMonthPercentageRent,
+				MonthsStruggling:        business.MonthsStruggling,
+			}
+			if tenant.Lease != nil {
+				report.Business.PercentageRent = tenant.Lease.PercentageRent
+				report.Business.Breakpoint = tenant.Lease.Breakpoint(date)
+			}
+		}
+		return report
+	}
+
+	if !unit.VacantSince.IsZero() {
+		report.VacantSince = &unit.VacantSince
+	}
+	for _, application := range unit.Applications {
+		report.Applications = append(report.Applications, &ApplicationReport{
+			ID:            application.ID,
+			AppliedDate:   application.AppliedDate,
+			RespondBy:     application.AppliedDate.AddDate(0, 0, components.ApplicationResponseDays),
+			MonthlyIncome: application.MonthlyIncome,
+			Rent:          application.Rent,
+			DesiredRent:   application.DesiredRent,
+			LeaseMonths:   application.LeaseMonths,
+			Screened:      application.Screened,
+			CreditScore:   application.CreditScore,
+		})
+	}
+	return report
+}
@@ -0,0 +1,160 @@
+package systems
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+	"github.com/markbmullins/city-developer/pkg/entities"
+)
+
+/*
+===========================================================
+
+	Auction system
+
+===========================================================
+
+1. **Scheduling**
+  - *Listings:* Each month there is a chance that the most valuable unowned property goes up for auction,
+    with a reserve of 90% of its market value.
+  - *Foreclosures:* Properties foreclosed by the bankruptcy system go up for auction with a reserve of 70%.
+    They stay with their owners until the auction settles, and can't be sold or have their shares traded meanwhile.
+  - Auctions are announced a week before bidding opens, and bidding stays open for two weeks of game time.
+  - Properties up for auction cannot be bought with `buy_property`.
+
+2. **Bidding**
+  - Players bid with `place_bid` while bidding is open.
+  - The first bid must meet the reserve price; every later bid must beat the highest bid by the bid increment.
+  - Bidders must be able to afford their bid when they place it.
+
+3. **Settlement**
+  - When bidding closes, the property goes to the highest bidder who can still afford their bid and is not bankrupt.
+  - The winner pays their bid and takes ownership as if they had bought the property at that price.
+  - *Foreclosures:* The property is first sold on its owners' behalf at the winning bid: they share the proceeds
+    less capital gains tax and the borrower pays off any mortgage (see property_sale.go).
+    Foreclosures without a valid bid are sold at the property's sale price instead.
+  - Listings without a valid bid end unsold and the property stays on the market.
+  - Every settled auction is recorded on the auction house.
+
+===========================================================
+*/
+type AuctionSystem struct{}
+
+func (s *AuctionSystem) Update(world *ecs.World) {
+	gameTime, _ := world.GetCurrentGameTime()
+	if gameTime.IsPaused {
+		return
+	}
+
+	for _, property := range world.QueryByComponent("Auction") {
+		auction, _ := property.GetAuction()
+		if !gameTime.CurrentDate.Before(auction.EndDate) {
+			settleAuction(world, property, auction, gameTime.CurrentDate)
+		} else if !gameTime.CurrentDate.Before(auction.StartDate) {
+			auction.Status = components.AuctionOpen
+		}
+	}
+
+	auctionHouse, err := world.GetAuctionHouse()
+	if err != nil {
+		return
+	}
+	for !nextMonthStart(auctionHouse.LastScheduled).After(gameTime.CurrentDate) {
+		auctionHouse.LastScheduled = nextMonthStart(auctionHouse.LastScheduled)
+		if rand.Float64() < auctionHouse.MonthlyListingChance {
+			scheduleListingAuction(world, auctionHouse.LastScheduled)
+		}
+	}
+}
+
+// ScheduleAuction puts a property up for auction, returning false if it is already up for auction.
+func ScheduleAuction(world *ecs.World, property *ecs.Entity, reason components.AuctionReason, date time.Time) bool {
+	if IsUpForAuction(property) {
+		return false
+	}
+	auction := entities.CreateAuction(reason, PropertyValue(property), date)
+	if err := world.AddAuctionToProperty(property, auction); err != nil {
+		return false
+	}
+	fmt.Printf("%s auction scheduled for property ID %d with a reserve of %.2f\n", reason, property.ID, auction.ReservePrice)
+	return true
+}
+
+// IsInForeclosure reports whether the property is up for a foreclosure auction.
+func IsInForeclosure(property *ecs.Entity) bool {
+	auction, err := property.GetAuction()
+	return err == nil && auction.Reason == components.ForeclosureAuction
+}
+
+// IsUpForAuction reports whether the property has an auction scheduled or open.
+func IsUpForAuction(property *ecs.Entity) bool {
+	_, err := property.GetAuction()
+	return err == nil
+}
+
+// scheduleListingAuction puts the most valuable unowned property that is not already up for auction up for auction.
+func scheduleListingAuction(world *ecs.World, date time.Time) {
+	var listing *ecs.Entity
+	for _, property := range world.GetAllProperties() {
+		ownable, _ := property.GetOwnable()
+		if ownable == nil || ownable.Owned || IsUpForAuction(property) {
+			continue
+		}
+		if listing == nil || PropertyValue(property) > PropertyValue(listing) ||
+			(PropertyValue(property) == PropertyValue(listing) && property.ID < listing.ID) {
+			listing = property
+		}
+	}
+	if listing != nil {
+		ScheduleAuction(world, listing, components.ListingAuction, date)
+	}
+}
+
+// settleAuction sells the property to the highest bidder who can pay and removes the auction.
+func settleAuction(world *ecs.World, property *ecs.Entity, auction *components.Auction, date time.Time) {
+	result := components.AuctionResult{PropertyID: property.ID, Reason: auction.Reason, Date: date}
+
+	// Bids only ever go up, so the last affordable bid is the winning one
+	for i := len(auction.Bids) - 1; i >= 0; i-- {
+		bid := auction.Bids[i]
+		bidder := world.GetEntity(bid.BidderID)
+		if bidder == nil || isBankrupt(bidder) {
+			continue
+		}
+		if funds, err := bidder.GetFunds(); err != nil || funds.Amount < bid.Amount {
+			continue
+		}
+
+		bidder.PostTransaction(date, components.PropertyPurchase, -bid.Amount, property.ID, "Auction purchase")
+		if auction.Reason == components.ForeclosureAuction {
+			sellProperty(world, property, calculateSale(property, date, bid.Amount, bid.Amount))
+		}
+		ownable, _ := property.GetOwnable()
+		purchaseable, _ := property.GetPurchaseable()
+		ownable.Owned = true
+		ownable.OwnerID = bidder.ID
+		purchaseable.Cost = bid.Amount
+		purchaseable.PurchaseDate = date
+		world.BuyProperty(property.ID, bidder.ID)
+
+		result.Sold = true
+		result.WinnerID = bidder.ID
+		result.Price = bid.Amount
+		fmt.Printf("Property ID %d sold at auction to player ID %d for %.2f\n", property.ID, bidder.ID, bid.Amount)
+		break
+	}
+	if !result.Sold {
+		fmt.Printf("Auction for property ID %d ended without a sale\n", property.ID)
+		if auction.Reason == components.ForeclosureAuction {
+			SellProperty(world, property)
+		}
+	}
+
+	world.RemoveAuctionFromProperty(property)
+	if auctionHouse, err := world.GetAuctionHouse(); err == nil {
+		auctionHouse.Results = append(auctionHouse.Results, result)
+	}
+}
@@ -0,0 +1,126 @@
+package systems
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/*
+===========================================================
+
+	Bank system
+
+===========================================================
+
+1. **Policy Rate**
+  - Once a month the central bank moves its rate 25% of the way towards the target for the current economic phase.
+
+2. **Mortgages**
+  - New mortgages are offered at the policy rate plus a spread for their term, from 2.25% for ten years to 3.5% for thirty.
+  - A mortgage's rate is fixed when it is taken out.
+
+3. **Savings**
+  - Savings balances earn the policy rate minus the savings spread.
+  - Interest is paid into the player's funds on the first of every month.
+
+4. **Credit Lines**
+  - Players can borrow up to the loan-to-value limit of their properties' market value, less mortgage balances.
+  - Credit line balances are charged the policy rate plus the credit line spread.
+  - Interest is debited from the player's funds on the first of every month.
+  - Limits are recalculated monthly; a balance above the limit blocks new draws but is not called in.
+
+===========================================================
+*/
+type BankSystem struct{}
+
+const policyRateDriftRate = 0.25
+
+func (s *BankSystem) Update(world *ecs.World) {
+	gameTime, _ := world.GetCurrentGameTime()
+	if gameTime.IsPaused {
+		return
+	}
+	bank, err := world.GetCentralBank()
+	if err != nil {
+		return
+	}
+
+	for !nextMonthStart(bank.LastUpdated).After(gameTime.CurrentDate) {
+		if economy, err := world.GetEconomy(); err == nil {
+			if target, ok := bank.PhaseRates[economy.Phase]; ok {
+				bank.Rate += (target - bank.Rate) * policyRateDriftRate
+			}
+		}
+		bank.LastUpdated = nextMonthStart(bank.LastUpdated)
+	}
+
+	for _, player := range world.Players {
+		account, err := player.GetBankAccount()
+		if err != nil || isBankrupt(player) {
+			continue
+		}
+		if account.LastInterestDate.IsZero() {
+			account.LastInterestDate = monthStart(gameTime.CurrentDate)
+		}
+
+		for !nextMonthStart(account.LastInterestDate).After(gameTime.CurrentDate) {
+			account.LastInterestDate = nextMonthStart(account.LastInterestDate)
+			accrueBankInterest(player, account, bank, account.LastInterestDate)
+		}
+		account.CreditLimit = CreditLimit(world, player)
+	}
+}
+
+func accrueBankInterest(player *ecs.Entity, account *components.BankAccount, bank *components.CentralBank, date time.Time) {
+	if savingsInterest := account.SavingsBalance * bank.SavingsRate() / 12; savingsInterest > 0 {
+		player.PostTransaction(date, components.SavingsInterest, savingsInterest, 0, "Savings interest")
+	}
+	if creditInterest := account.CreditLineBalance * bank.CreditLineRate() / 12; creditInterest > 0 {
+		player.PostTransaction(date, components.CreditLineInterest, -creditInterest, 0, "Credit line interest")
+		fmt.Printf("Credit line interest of %.2f debited from player ID %d\n", creditInterest, player.ID)
+	}
+}
+
+// CreditLimit returns how much a player can borrow on their credit line, secured by
+// their share of the market value of their properties less the mortgages they owe.
+func CreditLimit(world *ecs.World, player *ecs.Entity) float64 {
+	bank, err := world.GetCentralBank()
+	if err != nil {
+		return 0
+	}
+
+	equity := 0.0
+	for _, property := range PropertiesHeldBy(world, player.ID) {
+		equity += playerPropertyEquity(property, player.ID)
+	}
+	if equity <= 0 {
+		return 0
+	}
+	return equity * bank.MaxLoanToValue / 100
+}
+
+// LoanRate returns the annual rate currently offered for a mortgage with the given term,
+// and false if no loan is offered for that term.
+func LoanRate(world *ecs.World, termMonths int) (float64, bool) {
+	spread, offered := components.LoanOffers[termMonths]
+	if !offered {
+		return 0, false
+	}
+	bank, err := world.GetCentralBank()
+	if err != nil {
+		return components.InitialPolicyRate + spread, true
+	}
+	return bank.MortgageRate(spread), true
+}
+
+// CurrentLoanOffers returns the annual rate currently offered for each mortgage term.
+func CurrentLoanOffers(world *ecs.World) map[int]float64 {
+	offers := map[int]float64{}
+	for termMonths := range components.LoanOffers {
+		offers[termMonths], _ = LoanRate(world, termMonths)
+	}
+	return offers
+}
@@ -0,0 +1,265 @@
+package systems
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/*
+===========================================================
+
+	Bankruptcy system
+
+===========================================================
+
+Actions check affordability before spending, but scheduled obligations (loan payments,
+expenses, interest) are always debited and can push a player's funds below zero.
+
+1. **Warning**
+  - A player whose funds drop below zero is warned and given a grace period to recover.
+  - A player who gets back to zero or above within the grace period is solvent again.
+
+2. **Forced Liquidation**
+  - Once the grace period runs out, assets are liquidated in this order until funds are no longer negative:
+  - *Savings:* Withdrawn first.
+  - *Properties:* Foreclosed, highest equity (value less mortgage) first, until their expected proceeds cover the shortfall.
+    Each goes up for foreclosure auction and is sold on its owners' behalf when the auction settles (see auction_system.go).
+  - *Shares:* Shares in properties managed by other players are offered to any player at the foreclosure reserve,
+    70% of their value, highest equity first. Shares nobody buys within three weeks are forfeited to the other
+    shareholders pro rata, so they stop sharing the property's costs with the player.
+  - Foreclosures and share sales still waiting to settle are called off if the player's funds recover.
+
+3. **Bankruptcy**
+  - A player still below zero after every asset has been liquidated and every foreclosure and share sale has settled is bankrupt.
+  - Remaining credit line debt is written off and the player can no longer take actions.
+
+===========================================================
+*/
+type BankruptcySystem struct{}
+
+func (s *BankruptcySystem) Update(world *ecs.World) {
+	gameTime, _ := world.GetCurrentGameTime()
+	if gameTime.IsPaused {
+		return
+	}
+
+	for _, player := range world.Players {
+		solvency, err := player.GetSolvency()
+		if err != nil || solvency.Status == components.Bankrupt {
+			continue
+		}
+		funds, _ := player.GetFunds()
+		date := gameTime.CurrentDate.Format("2006-01-02")
+
+		if funds.Amount >= 0 {
+			if solvency.Status == components.Warning {
+				solvency.Status = components.Solvent
+				solvency.Notices = append(solvency.Notices, fmt.Sprintf("%s: Funds recovered, account back in good standing", date))
+				callOffLiquidation(world, player)
+			}
+			continue
+		}
+
+		if solvency.Status == components.Solvent {
+			solvency.Status = components.Warning
+			solvency.NegativeSince = gameTime.CurrentDate
+			solvency.Notices = append(solvency.Notices, fmt.Sprintf("%s: Funds are negative (%.2f); assets will be liquidated in %d days", date, funds.Amount, components.BankruptcyGracePeriodDays))
+			continue
+		}
+
+		if gameTime.CurrentDate.Before(solvency.NegativeSince.AddDate(0, 0, components.BankruptcyGracePeriodDays)) {
+			continue
+		}
+
+		forfeitUnsoldShares(world, player, solvency, gameTime.CurrentDate)
+		liquidateAssets(world, player, solvency, date)
+		if funds.Amount >= 0 {
+			solvency.Status = components.Solvent
+			callOffLiquidation(world, player)
+			continue
+		}
+		if len(pendingForeclosures(world, player)) > 0 || len(pendingShareSales(world, player)) > 0 {
+			continue
+		}
+
+		declareBankruptcy(player, solvency, date)
+	}
+}
+
+// liquidateAssets withdraws savings, then forecloses properties and then puts shares in other players' properties
+// up for sale, highest equity first, until funds and the expected proceeds of the sales are not negative.
+func liquidateAssets(world *ecs.World, player *ecs.Entity, solvency *components.Solvency, date string) {
+	funds, _ := player.GetFunds()
+	gameTime, _ := world.GetCurrentGameTime()
+
+	if account, err := player.GetBankAccount(); err == nil && account.SavingsBalance > 0 {
+		withdrawal := account.SavingsBalance
+		account.SavingsBalance = 0
+		player.PostTransaction(gameTime.CurrentDate, components.SavingsWithdrawal, withdrawal, 0, "Forced savings withdrawal")
+		solvency.Notices = append(solvency.Notices, fmt.Sprintf("%s: Savings of %.2f withdrawn to cover negative funds", date, withdrawal))
+	}
+
+	expectedFunds := funds.Amount
+	for _, property := range pendingForeclosures(world, player) {
+		expectedFunds += playerPropertyEquity(property, player.ID)
+	}
+	for _, property := range pendingShareSales(world, player) {
+		ownable, _ := property.GetOwnable()
+		expectedFunds += ownable.ForcedOffer(player.ID).Price
+	}
+
+	properties := world.GetOwnedEntities(player.ID)
+	sort.SliceStable(properties, func(i, j int) bool {
+		return propertyEquity(properties[i]) > propertyEquity(properties[j])
+	})
+	for _, property := range properties {
+		if expectedFunds >= 0 {
+			return
+		}
+		if IsUpForAuction(property) || !ScheduleAuction(world, property, components.ForeclosureAuction, gameTime.CurrentDate) {
+			continue
+		}
+		expectedFunds += playerPropertyEquity(property, player.ID)
+		solvency.Notices = append(solvency.Notices, fmt.Sprintf("%s: Property ID %d put up for foreclosure auction to cover negative funds", date, property.ID))
+	}
+
+	holdings := []*ecs.Entity{}
+	for _, property := range PropertiesHeldBy(world, player.ID) {
+		ownable, _ := property.GetOwnable()
+		if ownable.OwnerID != player.ID && ownable.ForcedOffer(player.ID) == nil && !IsInForeclosure(property) {
+			holdings = append(holdings, property)
+		}
+	}
+	sort.SliceStable(holdings, func(i, j int) bool {
+		return playerPropertyEquity(holdings[i], player.ID) > playerPropertyEquity(holdings[j], player.ID)
+	})
+	for _, property := range holdings {
+		if expectedFunds >= 0 {
+			return
+		}
+		offer := offerSharesForLiquidation(property, player, gameTime.CurrentDate)
+		expectedFunds += offer.Price
+		solvency.Notices = append(solvency.Notices, fmt.Sprintf("%s: %.2f%% of property ID %d offered for %.2f to cover negative funds", date, offer.Percentage, property.ID, offer.Price))
+	}
+}
+
+// offerSharesForLiquidation lists all of the player's shares in the property for sale to any player at the foreclosure reserve,
+// replacing the player's other offers on it.
+func offerSharesForLiquidation(property *ecs.Entity, player *ecs.Entity, date time.Time) *components.ShareOffer {
+	ownable, _ := property.GetOwnable()
+	share := ownable.Share(player.ID)
+	withdrawShareOffers(ownable, player.ID)
+	ownable.ShareOffers = append(ownable.ShareOffers, components.ShareOffer{
+		SellerID:   player.ID,
+		Percentage: share,
+		Price:      math.Round(PropertyValue(property) * share / 100 * components.ForeclosureReservePercentage / 100),
+		Forced:     true,
+		Deadline:   date.AddDate(0, 0, components.ForcedShareSaleDays),
+	})
+	return ownable.ForcedOffer(player.ID)
+}
+
+// pendingShareSales returns the properties in which the player's shares are up for sale to cover negative funds.
+func pendingShareSales(world *ecs.World, player *ecs.Entity) []*ecs.Entity {
+	properties := []*ecs.Entity{}
+	for _, property := range PropertiesHeldBy(world, player.ID) {
+		if ownable, _ := property.GetOwnable(); ownable.ForcedOffer(player.ID) != nil {
+			properties = append(properties, property)
+		}
+	}
+	return properties
+}
+
+// forfeitUnsoldShares hands the shares of forced sales that passed their deadline unsold to the property's
+// other shareholders, pro rata to their shares.
+func forfeitUnsoldShares(world *ecs.World, player *ecs.Entity, solvency *components.Solvency, date time.Time) {
+	for _, property := range pendingShareSales(world, player) {
+		ownable, _ := property.GetOwnable()
+		if date.Before(ownable.ForcedOffer(player.ID).Deadline) {
+			continue
+		}
+		withdrawShareOffers(ownable, player.ID)
+
+		share := ownable.Share(player.ID)
+		others := map[int]float64{}
+		for playerID, percentage := range ownable.Shareholders() {
+			if playerID != player.ID {
+				others[playerID] = percentage
+			}
+		}
+		recipients := SortedShareholderIDs(others)
+		for i, playerID := range recipients {
+			percentage := share * others[playerID] / (100 - share)
+			if i == len(recipients)-1 {
+				// The last shareholder takes whatever is left so no rounding remainder stays with the player
+				percentage = ownable.Share(player.ID)
+			}
+			TransferShares(world, property, player.ID, playerID, percentage)
+		}
+		solvency.Notices = append(solvency.Notices, fmt.Sprintf("%s: Unsold %.2f%% of property ID %d forfeited to its other shareholders", date.Format("2006-01-02"), share, property.ID))
+	}
+}
+
+// withdrawShareOffers removes every offer the player has made for shares of the property.
+func withdrawShareOffers(ownable *components.Ownable, playerID int) {
+	offers := []components.ShareOffer{}
+	for _, offer := range ownable.ShareOffers {
+		if offer.SellerID != playerID {
+			offers = append(offers, offer)
+		}
+	}
+	ownable.ShareOffers = offers
+}
+
+// pendingForeclosures returns the properties the player manages that are up for foreclosure auction.
+func pendingForeclosures(world *ecs.World, player *ecs.Entity) []*ecs.Entity {
+	properties := []*ecs.Entity{}
+	for _, property := range world.GetOwnedEntities(player.ID) {
+		if IsInForeclosure(property) {
+			properties = append(properties, property)
+		}
+	}
+	return properties
+}
+
+// callOffLiquidation cancels the foreclosure auctions and forced share sales of a player whose funds have recovered.
+func callOffLiquidation(world *ecs.World, player *ecs.Entity) {
+	for _, property := range pendingForeclosures(world, player) {
+		world.RemoveAuctionFromProperty(property)
+		fmt.Printf("Foreclosure auction for property ID %d called off\n", property.ID)
+	}
+	for _, property := range pendingShareSales(world, player) {
+		ownable, _ := property.GetOwnable()
+		withdrawShareOffers(ownable, player.ID)
+		fmt.Printf("Forced sale of player ID %d's shares in property ID %d called off\n", player.ID, property.ID)
+	}
+}
+
+func declareBankruptcy(player *ecs.Entity, solvency *components.Solvency, date string) {
+	if account, err := player.GetBankAccount(); err == nil {
+		account.CreditLineBalance = 0
+		account.CreditLimit = 0
+	}
+	solvency.Status = components.Bankrupt
+	solvency.Notices = append(solvency.Notices, fmt.Sprintf("%s: All assets liquidated and funds still negative; player is bankrupt", date))
+	fmt.Printf("Player ID %d is bankrupt\n", player.ID)
+}
+
+// propertyEquity is the market value of a property less its outstanding mortgage.
+func propertyEquity(property *ecs.Entity) float64 {
+	equity := PropertyValue(property)
+	if loan, err := property.GetLoan(); err == nil {
+		equity -= loan.OutstandingPrincipal
+	}
+	return equity
+}
+
+func isBankrupt(player *ecs.Entity) bool {
+	solvency, err := player.GetSolvency()
+	return err == nil && solvency.Status == components.Bankrupt
+}
@@ -0,0 +1,152 @@
+package systems
+
+import (
+	"testing"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+func TestBankruptcyStateMachine(t *testing.T) {
+	type step struct {
+		on         time.Time
+		addFunds   float64
+		wantStatus components.SolvencyStatus
+	}
+
+	tests := []struct {
+		name  string
+		setup func(world *ecs.World, player *ecs.Entity) // Gives the player assets before their funds go negative
+		steps []step
+		check func(t *testing.T, world *ecs.World, player *ecs.Entity)
+	}{
+		{
+			name: "stays solvent with funds",
+			steps: []step{
+				{on: date(2024, time.January, 1), addFunds: 100, wantStatus: components.Solvent},
+			},
+		},
+		{
+			name: "warned and recovers within the grace period",
+			steps: []step{
+				{on: date(2024, time.January, 1), addFunds: -500, wantStatus: components.Warning},
+				{on: date(2024, time.January, 20), addFunds: 1000, wantStatus: components.Solvent},
+				{on: date(2024, time.March, 1), wantStatus: components.Solvent},
+			},
+		},
+		{
+			name: "bankrupt once the grace period ends with nothing to liquidate",
+			setup: func(world *ecs.World, player *ecs.Entity) {
+				account, _ := player.GetBankAccount()
+				account.CreditLimit = 5000
+				account.CreditLineBalance = 2000
+			},
+			steps: []step{
+				{on: date(2024, time.January, 1), addFunds: -500, wantStatus: components.Warning},
+				{on: date(2024, time.January, 30), wantStatus: components.Warning},
+				{on: date(2024, time.January, 31), wantStatus: components.Bankrupt},
+				{on: date(2024, time.February, 15), addFunds: 1000, wantStatus: components.Bankrupt},
+			},
+			check: func(t *testing.T, world *ecs.World, player *ecs.Entity) {
+				if account, _ := player.GetBankAccount(); account.CreditLineBalance != 0 || account.CreditLimit != 0 {
+					t.Errorf("credit line %.2f of %.2f not written off", account.CreditLineBalance, account.CreditLimit)
+				}
+			},
+		},
+		{
+			name: "savings withdrawn to cover the shortfall",
+			setup: func(world *ecs.World, player *ecs.Entity) {
+				account, _ := player.GetBankAccount()
+				account.SavingsBalance = 1000
+			},
+			steps: []step{
+				{on: date(2024, time.January, 1), addFunds: -500, wantStatus: components.Warning},
+				{on: date(2024, time.January, 31), wantStatus: components.Solvent},
+			},
+			check: func(t *testing.T, world *ecs.World, player *ecs.Entity) {
+				if funds, _ := player.GetFunds(); funds.Amount != 500 {
+					t.Errorf("funds = %.2f, want 500", funds.Amount)
+				}
+			},
+		},
+		{
+			name: "foreclosure called off when funds recover",
+			setup: func(world *ecs.World, player *ecs.Entity) {
+				addTestProperty(world, player, 300000, 3000, date(2023, time.June, 1))
+			},
+			steps: []step{
+				{on: date(2024, time.January, 1), addFunds: -500, wantStatus: components.Warning},
+				{on: date(2024, time.January, 31), wantStatus: components.Warning},
+				{on: date(2024, time.February, 5), addFunds: 1000, wantStatus: components.Solvent},
+			},
+			check: func(t *testing.T, world *ecs.World, player *ecs.Entity) {
+				for _, property := range world.GetOwnedEntities(player.ID) {
+					if IsUpForAuction(property) {
+						t.Errorf("property ID %d still up for foreclosure", property.ID)
+					}
+				}
+			},
+		},
+		{
+			name: "waits for a pending foreclosure",
+			setup: func(world *ecs.World, player *ecs.Entity) {
+				addTestProperty(world, player, 300000, 3000, date(2023, time.June, 1))
+			},
+			steps: []step{
+				{on: date(2024, time.January, 1), addFunds: -500, wantStatus: components.Warning},
+				{on: date(2024, time.January, 31), wantStatus: components.Warning},
+				{on: date(2024, time.March, 1), wantStatus: components.Warning},
+			},
+			check: func(t *testing.T, world *ecs.World, player *ecs.Entity) {
+				if properties := pendingForeclosures(world, player); len(properties) != 1 {
+					t.Errorf("%d properties in foreclosure, want 1", len(properties))
+				}
+			},
+		},
+		{
+			name: "unsold shares forfeited before bankruptcy",
+			setup: func(world *ecs.World, player *ecs.Entity) {
+				partner := addTestPlayer(world, 0)
+				property := addTestProperty(world, partner, 300000, 3000, date(2023, time.June, 1))
+				SetShares(world, property, map[int]float64{partner.ID: 60, player.ID: 40})
+			},
+			steps: []step{
+				{on: date(2024, time.January, 1), addFunds: -500, wantStatus: components.Warning},
+				{on: date(2024, time.January, 31), wantStatus: components.Warning},
+				{on: date(2024, time.February, 20), wantStatus: components.Warning},
+				{on: date(2024, time.February, 21), wantStatus: components.Bankrupt},
+			},
+			check: func(t *testing.T, world *ecs.World, player *ecs.Entity) {
+				if properties := PropertiesHeldBy(world, player.ID); len(properties) != 0 {
+					t.Errorf("player still holds shares in %d properties", len(properties))
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			world := newTestWorld(test.steps[0].on)
+			player := addTestPlayer(world, 0)
+			if test.setup != nil {
+				test.setup(world, player)
+			}
+			gameTime, _ := world.GetCurrentGameTime()
+			funds, _ := player.GetFunds()
+			solvency, _ := player.GetSolvency()
+
+			for _, step := range test.steps {
+				gameTime.CurrentDate = step.on
+				funds.Amount += step.addFunds
+				(&BankruptcySystem{}).Update(world)
+				if solvency.Status != step.wantStatus {
+					t.Fatalf("%s: status = %s, want %s", step.on.Format(time.DateOnly), solvency.Status, step.wantStatus)
+				}
+			}
+			if test.check != nil {
+				test.check(t, world, player)
+			}
+		})
+	}
+}
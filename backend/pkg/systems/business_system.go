@@ -0,0 +1,170 @@
+package systems
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/*
+===========================================================
+
+	Business system
+
+===========================================================
+
+1. **Sales**
+  - At the start of every month each commercial tenant's sales for the month just ended are simulated:
+    the standard rent of their unit times the average sales-to-rent multiple of their kind of business, scaled by
+    the month's seasonality, neighborhood foot traffic, how well the business is run and up to 15% either way by chance.
+  - Seasonality follows the category of business (retail peaks in December, entertainment in summer),
+    or the subtype for seasonal trades such as ice cream shops, florists, jewelers and amusement parks.
+  - *Foot Traffic:* Higher in desirable neighborhoods and where more of the commercial properties are let,
+    from about 0.55 to 1.55 times average. Properties no player owns count as let.
+    Retail depends on it the most; offices and industrial tenants hardly at all.
+  - Sales are prorated for the days the business traded in the month.
+
+2. **Percentage Rent**
+  - Retail, food and beverage, service and entertainment leases charge a percentage of sales (6-8%) above the natural
+    breakpoint, the sales at which that percentage equals the base rent. It is charged along with the month's rent
+    (see rent_collection_system.go). Office and industrial leases are fixed rent only.
+
+3. **Closures**
+  - A business struggles in months its base rent is more than 15% of its sales, and struggling businesses
+    miss or part-pay rent twice as often.
+  - After six struggling months in a row the business closes and moves out, breaking its lease without paying a fee.
+  - Any business can also close with a small monthly chance, higher the longer it has been struggling.
+  - A closing business still pays the base and percentage rent for its final month; the tenant moves out once
+    rent has been collected (see tenant_system.go).
+
+===========================================================
+*/
+type BusinessSystem struct{}
+
+const (
+	salesVariation         = 0.15
+	struggleRiskMultiplier = 2.0
+)
+
+func (s *BusinessSystem) Update(world *ecs.World) {
+	gameTime, _ := world.GetCurrentGameTime()
+	if gameTime.IsPaused {
+		return
+	}
+
+	for _, property := range world.GetAllProperties() {
+		rentable, err := property.GetRentable()
+		if err != nil {
+			continue
+		}
+		for _, unit := range rentable.Units {
+			if unit.Tenant != nil && unit.Tenant.Business != nil && !unit.Tenant.Business.Closed {
+				updateBusiness(world, property, unit, gameTime.CurrentDate)
+			}
+		}
+	}
+}
+
+// updateBusiness simulates the sales of the unit's business for each month that has ended since its last update
+// and closes the business if it fails.
+func updateBusiness(world *ecs.World, property *ecs.Entity, unit *components.Unit, now time.Time) {
+	tenant := unit.Tenant
+	business := tenant.Business
+	for !nextMonthStart(business.LastUpdated).After(now) {
+		tradingStart := business.LastUpdated
+		monthStart := time.Date(tradingStart.Year(), tradingStart.Month(), 1, 0, 0, 0, 0, tradingStart.Location())
+		business.LastUpdated = nextMonthStart(tradingStart)
+
+		monthlySales := simulateSales(world, property, unit, business, monthStart)
+		tradedShare := float64(countDaysInRange(tradingStart, monthEnd(monthStart))) / float64(daysInMonth(monthStart))
+		business.LastMonthSales = monthlySales * tradedShare
+
+		baseRent := 0.0
+		business.LastMonthPercentageRent = 0
+		if lease := tenant.Lease; lease != nil {
+			baseRent = lease.RentOn(monthStart)
+			overage := business.LastMonthSales - lease.Breakpoint(monthStart)*tradedShare
+			if lease.PercentageRent > 0 && overage > 0 {
+				business.LastMonthPercentageRent = roundToNearest5(overage * lease.PercentageRent / 100)
+			}
+		}
+		business.PercentageRentDue += business.LastMonthPercentageRent
+
+		if baseRent > components.MaxOccupancyCostRatio*monthlySales {
+			business.MonthsStruggling++
+		} else {
+			business.MonthsStruggling = 0
+		}
+		if business.MonthsStruggling >= components.BusinessFailureMonths ||
+			rand.Float64() < components.BusinessClosureChance*float64(1+business.MonthsStruggling) {
+			fmt.Printf("Business in property ID %d unit %d closed after %d struggling months\n", property.ID, unit.Number, business.MonthsStruggling)
+			business.Closed = true
+			return
+		}
+	}
+}
+
+// simulateSales returns the business's sales for a full month starting at monthStart.
+func simulateSales(world *ecs.World, property *ecs.Entity, unit *components.Unit, business *components.Business, monthStart time.Time) float64 {
+	profile := business.Profile()
+	sales := UnitStandardRent(world, property, unit, monthStart) * profile.SalesToRent
+	sales *= profile.Seasonality[monthStart.Month()-1]
+	sales *= math.Pow(footTraffic(world, property), profile.FootTrafficSensitivity)
+	sales *= business.Quality
+	return sales * (1 + salesVariation*(2*rand.Float64()-1))
+}
+
+// footTraffic is how busy the property's neighborhood is compared to average, from its desirability
+// and the share of its commercial properties that are let.
+func footTraffic(world *ecs.World, property *ecs.Entity) float64 {
+	groupable, err := property.GetGroupable()
+	if err != nil {
+		return 1
+	}
+
+	traffic := 1.0
+	if neighborhood := world.GetNeighborhood(groupable.GroupID); neighborhood != nil {
+		if desirability, err := neighborhood.GetDesirability(); err == nil {
+			traffic = 0.7 + 0.6*desirability.Value/100
+		}
+	}
+
+	units, let := 0, 0
+	for _, groupProperty := range propertiesInGroup(world, groupable.GroupID) {
+		classifiable, err := groupProperty.GetClassifiable()
+		if err != nil || classifiable.Type != components.Commercial {
+			continue
+		}
+		rentable, err := groupProperty.GetRentable()
+		if err != nil {
+			continue
+		}
+		units += len(rentable.Units)
+		if ownable, err := groupProperty.GetOwnable(); err == nil && !ownable.Owned {
+			let += len(rentable.Units)
+		} else {
+			let += rentable.OccupiedUnits()
+		}
+	}
+	if units > 0 {
+		traffic *= 0.8 + 0.4*float64(let)/float64(units)
+	}
+	return traffic
+}
+
+// businessRiskMultiplier scales the chance of a commercial tenant missing or part-paying rent while their business struggles.
+func businessRiskMultiplier(tenant *components.Tenant) float64 {
+	if tenant.Business != nil && tenant.Business.MonthsStruggling > 0 {
+		return struggleRiskMultiplier
+	}
+	return 1
+}
+
+// businessQuality rolls how well an applicant runs their business. Applicants with better credit tend to run better businesses.
+func businessQuality(application *components.TenantApplication) float64 {
+	return 1.15 - 0.45*application.CreditRisk + 0.1*(2*rand.Float64()-1)
+}
@@ -0,0 +1,158 @@
+package systems
+
+import (
+	"math"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/*
+===========================================================
+
+	Cash flow forecast
+
+===========================================================
+
+Projects a player's cash flows for the coming months, starting with the next full month:
+  - **Rent:** The sum over units of calculateUnitMonthlyRent for each month of the hypothetical calendar, including upgrades
+    as they complete, weighted by the chance of finding a tenant for units that are vacant today.
+    Tenants pay the rent in their lease, with escalations, until it ends.
+  - **Operating Expenses:** The same breakdown charged by the rent collection system.
+  - **Property Tax:** The unpaid bill (or an estimate at the current value) in the neighborhood's due month.
+  - **Debt Service:** Mortgage payments amortized on a copy of each loan.
+  - **Co-ownership:** Rent, expenses and taxes are the player's share; debt service only counts mortgages they owe.
+
+The forecast only reads the world; nothing is charged, paid or recorded.
+
+===========================================================
+*/
+
+type PropertyForecast struct {
+	PropertyID        int      `json:"property_id"`
+	Rent              float64  `json:"rent"`
+	OperatingExpenses float64  `json:"operating_expenses"`
+	PropertyTax       float64  `json:"property_tax"`
+	DebtService       float64  `json:"debt_service"`
+	NetCashFlow       float64  `json:"net_cash_flow"`
+	CompletedUpgrades []string `json:"completed_upgrades"`
+}
+
+type MonthForecast struct {
+	MonthStart        time.Time           `json:"month_start"`
+	MonthEnd          time.Time           `json:"month_end"`
+	Rent              float64             `json:"rent"`
+	OperatingExpenses float64             `json:"operating_expenses"`
+	PropertyTax       float64             `json:"property_tax"`
+	DebtService       float64             `json:"debt_service"`
+	NetCashFlow       float64             `json:"net_cash_flow"`
+	Properties        []*PropertyForecast `json:"properties"`
+}
+
+type CashFlowForecast struct {
+	PlayerID int              `json:"player_id"`
+	Months   []*MonthForecast `json:"months"`
+}
+
+// ForecastCashFlow projects the player's cash flows for the given number of months without changing the world.
+func ForecastCashFlow(world *ecs.World, player *ecs.Entity, months int) *CashFlowForecast {
+	gameTime, _ := world.GetCurrentGameTime()
+	forecast := &CashFlowForecast{PlayerID: player.ID, Months: []*MonthForecast{}}
+
+	properties := PropertiesHeldBy(world, player.ID)
+
+	// Outstanding principal on a copy of each mortgage, amortized month by month
+	outstandingPrincipal := map[int]float64{}
+	for _, property := range properties {
+		if loan, err := property.GetLoan(); err == nil {
+			outstandingPrincipal[property.ID] = loan.OutstandingPrincipal
+		}
+	}
+
+	firstMonth := nextMonthStart(gameTime.CurrentDate)
+	for i := 0; i < months; i++ {
+		start := firstMonth.AddDate(0, i, 0)
+		month := &MonthForecast{MonthStart: start, MonthEnd: monthEnd(start), Properties: []*PropertyForecast{}}
+
+		for _, property := range properties {
+			propertyForecast := forecastPropertyMonth(world, player.ID, property, month.MonthStart, month.MonthEnd, outstandingPrincipal)
+			month.Rent += propertyForecast.Rent
+			month.OperatingExpenses += propertyForecast.OperatingExpenses
+			month.PropertyTax += propertyForecast.PropertyTax
+			month.DebtService += propertyForecast.DebtService
+			month.NetCashFlow += propertyForecast.NetCashFlow
+			month.Properties = append(month.Properties, propertyForecast)
+		}
+
+		forecast.Months = append(forecast.Months, month)
+	}
+
+	return forecast
+}
+
+func forecastPropertyMonth(world *ecs.World, playerID int, property *ecs.Entity, start, end time.Time, outstandingPrincipal map[int]float64) *PropertyForecast {
+	propertyForecast := &PropertyForecast{PropertyID: property.ID, CompletedUpgrades: []string{}}
+	share := ownershipFraction(property, playerID)
+
+	rent := 0.0
+	if rentable, err := property.GetRentable(); err == nil {
+		for _, unit := range rentable.Units {
+			if tenant := unit.Tenant; tenant != nil && tenant.Lease != nil && start.Before(tenant.Lease.EndDate) {
+				rent += tenant.Lease.RentOn(start)
+				continue
+			}
+			rent += calculateUnitMonthlyRent(property, unit, start, end, world) * expectedOccupancy(world, property, unit, start)
+		}
+	}
+	propertyForecast.Rent = rent * share
+	if expenses := calculateOperatingExpenses(world, property, rent, start, end); expenses != nil {
+		propertyForecast.OperatingExpenses = expenses.TotalExpenses * share
+	}
+	propertyForecast.PropertyTax = forecastPropertyTax(world, property, start) * share
+
+	if loan, err := property.GetLoan(); err == nil && loan.BorrowerID == playerID && outstandingPrincipal[property.ID] > 0 {
+		interest := outstandingPrincipal[property.ID] * loan.AnnualRate / 12
+		payment := math.Min(loan.MonthlyPayment, outstandingPrincipal[property.ID]+interest)
+		outstandingPrincipal[property.ID] -= payment - interest
+		propertyForecast.DebtService = payment
+	}
+
+	if upgradable, err := property.GetUpgradable(); err == nil {
+		for _, upgrade := range upgradable.AppliedUpgrades {
+			completionDate := upgrade.PurchaseDate.AddDate(0, 0, upgrade.DaysToComplete)
+			if !completionDate.Before(start) && !completionDate.After(end) {
+				propertyForecast.CompletedUpgrades = append(propertyForecast.CompletedUpgrades, upgrade.Name)
+			}
+		}
+	}
+
+	propertyForecast.NetCashFlow = propertyForecast.Rent - propertyForecast.OperatingExpenses - propertyForecast.PropertyTax - propertyForecast.DebtService
+	return propertyForecast
+}
+
+// forecastPropertyTax returns the property tax expected to be paid in the month starting at monthStart.
+func forecastPropertyTax(world *ecs.World, property *ecs.Entity, monthStart time.Time) float64 {
+	taxable, err := property.GetTaxable()
+	if err != nil {
+		return 0
+	}
+	groupable, _ := property.GetGroupable()
+	neighborhood := world.GetNeighborhood(groupable.GroupID)
+	if neighborhood == nil {
+		return 0
+	}
+	district, err := neighborhood.GetTaxDistrict()
+	if err != nil {
+		return 0
+	}
+
+	for _, bill := range taxable.Bills {
+		if bill.DueDate.Year() == monthStart.Year() && bill.DueDate.Month() == monthStart.Month() {
+			return bill.Balance()
+		}
+	}
+	if monthStart.Month() != district.DueMonth || taxable.LastAssessmentYear >= monthStart.Year() {
+		return 0
+	}
+	return PropertyValue(property) * district.MillageRate / 1000
+}
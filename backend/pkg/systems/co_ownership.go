@@ -0,0 +1,164 @@
+package systems
+
+import (
+	"sort"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/*
+===========================================================
+
+	Co-ownership
+
+===========================================================
+
+1. **Shares**
+  - A property can be owned in percentage shares by several players. A property without shares is owned outright by its owner.
+  - The largest shareholder is the managing owner; they decide on rents, repairs, upgrades and selling the property.
+  - Shares can be offered to any player or reserved for one, and are bought by accepting the offer.
+  - A player buying with partners pays the full price and offers each partner their share at cost; partners only join
+    if they accept.
+
+2. **Pro Rata Split**
+  - Rent, late fees, security deposits, operating expenses, property tax, repairs, upgrade costs,
+    sale proceeds and capital gains tax are split between the shareholders by their percentage.
+  - A mortgage stays with the player who took it out, who makes every payment and pays it off when the property is sold.
+  - Security deposits held for tenants move with the shares: a seller pays the buyer their part of the deposits,
+    since the buyer shares in refunding them.
+
+3. **Reporting**
+  - Balance sheets, credit limits, forecasts and portfolio metrics count each player's share of a property.
+  - Liquidation forecloses properties the player manages, with partners receiving their share of the proceeds,
+    and puts the player's shares in other players' properties up for sale (see bankruptcy_system.go).
+
+===========================================================
+*/
+
+// PostPropertyTransaction splits an amount received or spent for a property between its shareholders pro rata.
+func PostPropertyTransaction(world *ecs.World, property *ecs.Entity, date time.Time, category components.TransactionCategory, amount float64, description string) {
+	ownable, err := property.GetOwnable()
+	if err != nil {
+		return
+	}
+	shareholders := ownable.Shareholders()
+	for _, playerID := range SortedShareholderIDs(shareholders) {
+		if player := world.GetEntity(playerID); player != nil {
+			player.PostTransaction(date, category, amount*shareholders[playerID]/100, property.ID, description)
+		}
+	}
+}
+
+// CanShareholdersAfford reports whether every shareholder can pay their share of the amount,
+// returning the first player who cannot.
+func CanShareholdersAfford(world *ecs.World, property *ecs.Entity, amount float64) (bool, *ecs.Entity) {
+	ownable, _ := property.GetOwnable()
+	shareholders := ownable.Shareholders()
+	for _, playerID := range SortedShareholderIDs(shareholders) {
+		player := world.GetEntity(playerID)
+		if player == nil {
+			continue
+		}
+		if funds, err := player.GetFunds(); err != nil || funds.Amount < amount*shareholders[playerID]/100 {
+			return false, player
+		}
+	}
+	return true, nil
+}
+
+// PropertiesHeldBy returns every property the player owns a share of, ordered by ID.
+func PropertiesHeldBy(world *ecs.World, playerID int) []*ecs.Entity {
+	properties := []*ecs.Entity{}
+	for _, property := range world.GetAllProperties() {
+		if ownable, err := property.GetOwnable(); err == nil && ownable.Share(playerID) > 0 {
+			properties = append(properties, property)
+		}
+	}
+	sort.Slice(properties, func(i, j int) bool { return properties[i].ID < properties[j].ID })
+	return properties
+}
+
+// SetShares replaces the owners of a property and makes the largest shareholder its managing owner.
+// Percentages must add up to 100.
+func SetShares(world *ecs.World, property *ecs.Entity, shares map[int]float64) {
+	ownable, _ := property.GetOwnable()
+	for playerID, percentage := range shares {
+		if percentage <= 0 {
+			delete(shares, playerID)
+		}
+	}
+
+	managingOwner := ownable.OwnerID
+	for _, playerID := range SortedShareholderIDs(shares) {
+		if shares[playerID] > shares[managingOwner] {
+			managingOwner = playerID
+		}
+	}
+	if managingOwner != ownable.OwnerID {
+		world.ChangePropertyOwnership(property.ID, ownable.OwnerID, managingOwner)
+		ownable.OwnerID = managingOwner
+	}
+
+	if len(shares) == 1 {
+		// A single owner holds the property outright
+		ownable.Shares = nil
+		return
+	}
+	ownable.Shares = shares
+}
+
+// TransferShares moves a percentage of a property from one player to another. The seller hands over the same
+// percentage of the tenants' security deposits, since the buyer shares in refunding them from now on.
+func TransferShares(world *ecs.World, property *ecs.Entity, sellerID, buyerID int, percentage float64) {
+	if deposits := propertySecurityDeposits(property) * percentage / 100; deposits > 0 {
+		gameTime, _ := world.GetCurrentGameTime()
+		TransferFunds(world, sellerID, buyerID, gameTime.CurrentDate, components.SecurityDeposit, deposits, property.ID, "Security deposits transferred with shares")
+	}
+
+	ownable, _ := property.GetOwnable()
+	shares := map[int]float64{}
+	for playerID, share := range ownable.Shareholders() {
+		shares[playerID] = share
+	}
+	shares[sellerID] -= percentage
+	shares[buyerID] += percentage
+	SetShares(world, property, shares)
+}
+
+// ownershipFraction returns the fraction of the property owned by the player, from 0 to 1.
+func ownershipFraction(property *ecs.Entity, playerID int) float64 {
+	ownable, err := property.GetOwnable()
+	if err != nil {
+		return 0
+	}
+	return ownable.Share(playerID) / 100
+}
+
+// playerPropertyEquity is the player's share of a property's market value less any mortgage they owe on it.
+func playerPropertyEquity(property *ecs.Entity, playerID int) float64 {
+	equity := PropertyValue(property) * ownershipFraction(property, playerID)
+	if loan, err := property.GetLoan(); err == nil && loan.BorrowerID == playerID {
+		equity -= loan.OutstandingPrincipal
+	}
+	return equity
+}
+
+// releaseOwnership clears the owners of a property that has left their hands.
+func releaseOwnership(ownable *components.Ownable) {
+	ownable.Owned = false
+	ownable.OwnerID = 0
+	ownable.Shares = nil
+	ownable.ShareOffers = nil
+}
+
+// SortedShareholderIDs returns the IDs of the players in a map of shares in ascending order, so shares are handled in a stable order.
+func SortedShareholderIDs(shares map[int]float64) []int {
+	playerIDs := make([]int, 0, len(shares))
+	for playerID := range shares {
+		playerIDs = append(playerIDs, playerID)
+	}
+	sort.Ints(playerIDs)
+	return playerIDs
+}
@@ -0,0 +1,145 @@
+package systems
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+	"github.com/markbmullins/city-developer/pkg/entities"
+)
+
+// addTestPartners adds three players and a property owned by them in the given shares, by player index.
+// Without shares, the first player owns the property outright.
+func addTestPartners(world *ecs.World, shares map[int]float64) ([]*ecs.Entity, *ecs.Entity) {
+	players := []*ecs.Entity{addTestPlayer(world, 0), addTestPlayer(world, 0), addTestPlayer(world, 0)}
+	property := addTestProperty(world, players[0], 300000, 3000, date(2024, time.January, 1))
+	if len(shares) > 0 {
+		playerShares := map[int]float64{}
+		for index, share := range shares {
+			playerShares[players[index].ID] = share
+		}
+		SetShares(world, property, playerShares)
+	}
+	return players, property
+}
+
+func TestPostPropertyTransaction(t *testing.T) {
+	tests := []struct {
+		name   string
+		shares map[int]float64
+		amount float64
+		want   []float64 // Amount posted to each player
+	}{
+		{name: "owned outright", shares: nil, amount: 1000, want: []float64{1000, 0, 0}},
+		{name: "two partners", shares: map[int]float64{0: 60, 1: 40}, amount: 1000, want: []float64{600, 400, 0}},
+		{name: "three partners", shares: map[int]float64{0: 50, 1: 30, 2: 20}, amount: -2500, want: []float64{-1250, -750, -500}},
+		{name: "managed by the largest shareholder", shares: map[int]float64{0: 25, 1: 75}, amount: 1000, want: []float64{250, 750, 0}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			world := newTestWorld(date(2024, time.March, 1))
+			players, property := addTestPartners(world, test.shares)
+
+			PostPropertyTransaction(world, property, date(2024, time.March, 1), components.RentIncome, test.amount, "Rent collected")
+
+			for index, player := range players {
+				if got := ledgerTotal(player, components.RentIncome, property.ID); math.Abs(got-test.want[index]) > 0.001 {
+					t.Errorf("player %d received %.2f, want %.2f", index, got, test.want[index])
+				}
+			}
+		})
+	}
+}
+
+func TestTransferShares(t *testing.T) {
+	tests := []struct {
+		name         string
+		shares       map[int]float64
+		seller       int
+		buyer        int
+		percentage   float64
+		wantShares   map[int]float64 // By player index; nil when owned outright
+		wantManager  int
+		wantDeposits []float64 // Security deposits paid or received by each player
+	}{
+		{
+			name:         "selling part of a property owned outright",
+			seller:       0,
+			buyer:        1,
+			percentage:   40,
+			wantShares:   map[int]float64{0: 60, 1: 40},
+			wantManager:  0,
+			wantDeposits: []float64{-1200, 1200, 0},
+		},
+		{
+			name:         "buyer becomes the largest shareholder",
+			shares:       map[int]float64{0: 60, 1: 40},
+			seller:       0,
+			buyer:        1,
+			percentage:   30,
+			wantShares:   map[int]float64{0: 30, 1: 70},
+			wantManager:  1,
+			wantDeposits: []float64{-900, 900, 0},
+		},
+		{
+			name:         "new partner joins",
+			shares:       map[int]float64{0: 60, 1: 40},
+			seller:       1,
+			buyer:        2,
+			percentage:   15,
+			wantShares:   map[int]float64{0: 60, 1: 25, 2: 15},
+			wantManager:  0,
+			wantDeposits: []float64{0, -450, 450},
+		},
+		{
+			name:         "last partner sells out",
+			shares:       map[int]float64{0: 60, 1: 40},
+			seller:       1,
+			buyer:        0,
+			percentage:   40,
+			wantShares:   nil,
+			wantManager:  0,
+			wantDeposits: []float64{1200, -1200, 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			world := newTestWorld(date(2024, time.March, 1))
+			players, property := addTestPartners(world, test.shares)
+			rentable, _ := property.GetRentable()
+			rentable.Units[0].Tenant = entities.CreateTenant(components.Residential, 3000, 3000, 12, 0, date(2024, time.January, 1))
+
+			TransferShares(world, property, players[test.seller].ID, players[test.buyer].ID, test.percentage)
+
+			ownable, _ := property.GetOwnable()
+			var gotShares map[int]float64
+			if ownable.Shares != nil {
+				gotShares = map[int]float64{}
+				for index, player := range players {
+					if share := ownable.Shares[player.ID]; share > 0 {
+						gotShares[index] = share
+					}
+				}
+			}
+			if !reflect.DeepEqual(gotShares, test.wantShares) {
+				t.Errorf("shares = %v, want %v", gotShares, test.wantShares)
+			}
+			if manager := players[test.wantManager]; ownable.OwnerID != manager.ID {
+				t.Errorf("managing owner = %d, want %d", ownable.OwnerID, manager.ID)
+			}
+			if owned := world.GetOwnedEntities(players[test.wantManager].ID); len(owned) != 1 || owned[0] != property {
+				t.Errorf("property not indexed under its managing owner")
+			}
+			for index, player := range players {
+				if got := ledgerTotal(player, components.SecurityDeposit, property.ID); got != test.wantDeposits[index] {
+					t.Errorf("player %d deposits = %.2f, want %.2f", index, got, test.wantDeposits[index])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package systems
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/*
+===========================================================
+
+	Economy system
+
+===========================================================
+
+1. **Economic Cycle**
+  - The economy moves through expansion, peak, recession and recovery, in the order configured by each phase's Next.
+  - A phase lasts at least MinMonths; after that it ends with TransitionChance every month, and always ends after MaxMonths.
+
+2. **Monthly Update**
+  - The rent multiplier and vacancy rate drift 20% of the way towards the phase targets each month.
+  - The property price index compounds by the phase's monthly price growth.
+
+3. **Effects**
+  - Rents are multiplied by the rent multiplier, and the vacancy rate makes vacant units take longer to let.
+  - Property values are scaled by the price index (see the valuation system).
+  - The central bank moves its policy rate towards a target for each phase, and new loans are priced off it (see bank_system.go).
+
+===========================================================
+*/
+type EconomySystem struct{}
+
+const economyDriftRate = 0.2
+
+func (s *EconomySystem) Update(world *ecs.World) {
+	gameTime, _ := world.GetCurrentGameTime()
+	if gameTime.IsPaused {
+		return
+	}
+	economy, err := world.GetEconomy()
+	if err != nil {
+		return
+	}
+
+	for !nextMonthStart(economy.LastUpdated).After(gameTime.CurrentDate) {
+		advanceEconomyMonth(economy)
+		economy.LastUpdated = nextMonthStart(economy.LastUpdated)
+	}
+}
+
+func advanceEconomyMonth(economy *components.Economy) {
+	settings := economy.Phases[economy.Phase]
+
+	economy.RentMultiplier += (settings.RentMultiplier - economy.RentMultiplier) * economyDriftRate
+	economy.VacancyRate += (settings.VacancyRate - economy.VacancyRate) * economyDriftRate
+	economy.PriceIndex *= 1 + settings.MonthlyPriceGrowth/100
+	economy.MonthsInPhase++
+
+	if economy.MonthsInPhase < settings.MinMonths {
+		return
+	}
+	if economy.MonthsInPhase >= settings.MaxMonths || rand.Float64() < settings.TransitionChance {
+		fmt.Printf("Economy moved from %s to %s\n", economy.Phase, settings.Next)
+		economy.Phase = settings.Next
+		economy.MonthsInPhase = 0
+	}
+}
+
+// economicRentMultiplier is the economy's rent multiplier. Vacancies are simulated by the tenant system.
+func economicRentMultiplier(world *ecs.World) float64 {
+	economy, err := world.GetEconomy()
+	if err != nil {
+		return 1
+	}
+	return economy.RentMultiplier
+}
+
+func priceIndexValueFactor(world *ecs.World) float64 {
+	economy, err := world.GetEconomy()
+	if err != nil {
+		return 1
+	}
+	return economy.PriceIndex / 100
+}
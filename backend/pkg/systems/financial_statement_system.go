@@ -0,0 +1,282 @@
+package systems
+
+import (
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/*
+===========================================================
+
+	Financial statement system
+
+===========================================================
+
+- Every player's books are closed once a month, on the first update of the following month.
+- The income statement and cash flow statement are built from the ledger transactions dated within the month.
+- Rent, late fees and operating expenses are booked on the last day of the month they cover, so they close with that month.
+- The balance sheet is a snapshot of cash, savings, property values, loan and credit line balances, unpaid taxes and security deposits at the end of the month.
+- When several months close in one update, cash, savings, debt and deposits are rolled back over the ledger transactions booked after each month's end; property values are taken at closing.
+- Quarterly and yearly statements are aggregated from the closed monthly statements on request.
+- Statements can be restated in real terms: each month is divided by the inflation index recorded when it closed.
+
+===========================================================
+*/
+type FinancialStatementSystem struct{}
+
+type StatementPeriod string
+
+const (
+	MonthlyPeriod   StatementPeriod = "month"
+	QuarterlyPeriod StatementPeriod = "quarter"
+	YearlyPeriod    StatementPeriod = "year"
+)
+
+func (s *FinancialStatementSystem) Update(world *ecs.World) {
+	gameTime, _ := world.GetCurrentGameTime()
+	if gameTime.IsPaused {
+		return
+	}
+
+	for _, player := range world.Players {
+		statements, err := player.GetFinancialStatements()
+		if err != nil {
+			continue
+		}
+		if statements.OpenPeriodStart.IsZero() {
+			statements.OpenPeriodStart = monthStart(gameTime.CurrentDate)
+		}
+
+		for !nextMonthStart(statements.OpenPeriodStart).After(gameTime.CurrentDate) {
+			periodEnd := monthEnd(statements.OpenPeriodStart)
+			statement := BuildStatement(world, player, statements.OpenPeriodStart, periodEnd)
+			statements.Monthly = append(statements.Monthly, statement)
+			statements.OpenPeriodStart = nextMonthStart(statements.OpenPeriodStart)
+		}
+	}
+}
+
+// BuildStatement builds the financial statement for a player over the inclusive date range,
+// with the balance sheet as it stood at the end of the range.
+func BuildStatement(world *ecs.World, player *ecs.Entity, periodStart, periodEnd time.Time) *components.FinancialStatement {
+	statement := &components.FinancialStatement{
+		PeriodStart:    periodStart,
+		PeriodEnd:      periodEnd,
+		Income:         newIncomeStatement(),
+		CashFlow:       newCashFlowStatement(),
+		BalanceSheet:   buildBalanceSheet(world, player, periodEnd),
+		InflationIndex: InflationIndex(world),
+	}
+
+	ledger, err := player.GetLedger()
+	if err != nil {
+		return statement
+	}
+	for _, transaction := range ledger.Transactions {
+		if transaction.Date.Before(periodStart) || transaction.Date.After(periodEnd) {
+			continue
+		}
+		addIncomeLine(&statement.Income, transaction.Category, transaction.Amount)
+		addCashFlowLine(&statement.CashFlow, transaction.Category, transaction.Amount)
+	}
+
+	return statement
+}
+
+// AggregateStatements combines consecutive monthly statements into quarterly or yearly statements.
+// Flows are summed and the balance sheet is taken from the last month of each period.
+func AggregateStatements(monthly []*components.FinancialStatement, period StatementPeriod) []*components.FinancialStatement {
+	if period == MonthlyPeriod {
+		return monthly
+	}
+
+	aggregated := []*components.FinancialStatement{}
+	var current *components.FinancialStatement
+	for _, month := range monthly {
+		if current == nil || periodKey(current.PeriodStart, period) != periodKey(month.PeriodStart, period) {
+			current = &components.FinancialStatement{
+				PeriodStart: month.PeriodStart,
+				Income:      newIncomeStatement(),
+				CashFlow:    newCashFlowStatement(),
+			}
+			aggregated = append(aggregated, current)
+		}
+
+		current.PeriodEnd = month.PeriodEnd
+		current.BalanceSheet = month.BalanceSheet
+		current.InflationIndex = month.InflationIndex
+		for category, amount := range month.Income.Lines {
+			addIncomeLine(&current.Income, category, amount)
+		}
+		for _, lines := range []map[components.TransactionCategory]float64{month.CashFlow.Operating, month.CashFlow.Investing, month.CashFlow.Financing} {
+			for category, amount := range lines {
+				addCashFlowLine(&current.CashFlow, category, amount)
+			}
+		}
+	}
+
+	return aggregated
+}
+
+// RealStatements restates monthly statements in start of game dollars using the inflation index recorded on each.
+func RealStatements(monthly []*components.FinancialStatement) []*components.FinancialStatement {
+	restated := []*components.FinancialStatement{}
+	for _, month := range monthly {
+		index := month.InflationIndex
+		if index <= 0 {
+			index = 1
+		}
+
+		statement := &components.FinancialStatement{
+			PeriodStart:    month.PeriodStart,
+			PeriodEnd:      month.PeriodEnd,
+			Income:         newIncomeStatement(),
+			CashFlow:       newCashFlowStatement(),
+			BalanceSheet:   deflateBalanceSheet(month.BalanceSheet, index),
+			InflationIndex: 1,
+		}
+		for category, amount := range month.Income.Lines {
+			addIncomeLine(&statement.Income, category, amount/index)
+		}
+		for _, lines := range []map[components.TransactionCategory]float64{month.CashFlow.Operating, month.CashFlow.Investing, month.CashFlow.Financing} {
+			for category, amount := range lines {
+				addCashFlowLine(&statement.CashFlow, category, amount/index)
+			}
+		}
+		restated = append(restated, statement)
+	}
+	return restated
+}
+
+func deflateBalanceSheet(balanceSheet components.BalanceSheet, index float64) components.BalanceSheet {
+	return components.BalanceSheet{
+		Cash:              balanceSheet.Cash / index,
+		Savings:           balanceSheet.Savings / index,
+		PropertyValue:     balanceSheet.PropertyValue / index,
+		TotalAssets:       balanceSheet.TotalAssets / index,
+		LoanBalances:      balanceSheet.LoanBalances / index,
+		CreditLineBalance: balanceSheet.CreditLineBalance / index,
+		TaxesPayable:      balanceSheet.TaxesPayable / index,
+		SecurityDeposits:  balanceSheet.SecurityDeposits / index,
+		TotalLiabilities:  balanceSheet.TotalLiabilities / index,
+		Equity:            balanceSheet.Equity / index,
+	}
+}
+
+func periodKey(date time.Time, period StatementPeriod) int {
+	if period == QuarterlyPeriod {
+		return date.Year()*10 + (int(date.Month())-1)/3
+	}
+	return date.Year()
+}
+
+func newIncomeStatement() components.IncomeStatement {
+	return components.IncomeStatement{Lines: map[components.TransactionCategory]float64{}}
+}
+
+func newCashFlowStatement() components.CashFlowStatement {
+	return components.CashFlowStatement{
+		Operating: map[components.TransactionCategory]float64{},
+		Investing: map[components.TransactionCategory]float64{},
+		Financing: map[components.TransactionCategory]float64{},
+	}
+}
+
+func addIncomeLine(income *components.IncomeStatement, category components.TransactionCategory, amount float64) {
+	if !components.IncomeStatementCategories[category] {
+		return
+	}
+	income.Lines[category] += amount
+	if amount >= 0 {
+		income.TotalRevenue += amount
+	} else {
+		income.TotalExpenses += amount
+	}
+	income.NetIncome += amount
+}
+
+func addCashFlowLine(cashFlow *components.CashFlowStatement, category components.TransactionCategory, amount float64) {
+	switch components.TransactionActivities[category] {
+	case components.OperatingActivity:
+		cashFlow.Operating[category] += amount
+		cashFlow.OperatingCashFlow += amount
+	case components.InvestingActivity:
+		cashFlow.Investing[category] += amount
+		cashFlow.InvestingCashFlow += amount
+	case components.FinancingActivity:
+		cashFlow.Financing[category] += amount
+		cashFlow.FinancingCashFlow += amount
+	default:
+		return
+	}
+	cashFlow.NetCashFlow += amount
+}
+
+// buildBalanceSheet builds the player's balance sheet as it stood at the end of the given day.
+func buildBalanceSheet(world *ecs.World, player *ecs.Entity, date time.Time) components.BalanceSheet {
+	balanceSheet := components.BalanceSheet{}
+
+	if funds, err := player.GetFunds(); err == nil {
+		balanceSheet.Cash = funds.Amount
+	}
+	if account, err := player.GetBankAccount(); err == nil {
+		balanceSheet.Savings = account.SavingsBalance
+		balanceSheet.CreditLineBalance = account.CreditLineBalance
+	}
+	for _, property := range PropertiesHeldBy(world, player.ID) {
+		balanceSheet.PropertyValue += PropertyValue(property) * ownershipFraction(property, player.ID)
+	}
+	for _, property := range world.QueryByComponent("Loan") {
+		if loan, err := property.GetLoan(); err == nil && loan.BorrowerID == player.ID {
+			balanceSheet.LoanBalances += loan.OutstandingPrincipal
+		}
+	}
+	for _, property := range world.GetAllProperties() {
+		taxable, err := property.GetTaxable()
+		if err != nil {
+			continue
+		}
+		for _, bill := range taxable.Bills {
+			if bill.OwnerID == player.ID && bill.Year <= date.Year() && (!bill.Paid || bill.PaidDate.After(date)) {
+				balanceSheet.TaxesPayable += bill.Amount + bill.Penalty
+			}
+		}
+	}
+	balanceSheet.SecurityDeposits = securityDepositsHeld(world, player.ID)
+	unwindBalanceSheet(&balanceSheet, player, date)
+
+	balanceSheet.TotalAssets = balanceSheet.Cash + balanceSheet.Savings + balanceSheet.PropertyValue
+	balanceSheet.TotalLiabilities = balanceSheet.LoanBalances + balanceSheet.CreditLineBalance + balanceSheet.TaxesPayable + balanceSheet.SecurityDeposits
+	balanceSheet.Equity = balanceSheet.TotalAssets - balanceSheet.TotalLiabilities
+	return balanceSheet
+}
+
+// unwindBalanceSheet rolls cash, savings, debt and deposits back over the player's ledger transactions booked after the date.
+func unwindBalanceSheet(balanceSheet *components.BalanceSheet, player *ecs.Entity, date time.Time) {
+	ledger, err := player.GetLedger()
+	if err != nil {
+		return
+	}
+	for _, transaction := range ledger.Transactions {
+		if !transaction.Date.After(date) {
+			continue
+		}
+		balanceSheet.Cash -= transaction.Amount
+		switch transaction.Category {
+		case components.SavingsDeposit, components.SavingsWithdrawal:
+			balanceSheet.Savings += transaction.Amount
+		case components.CreditLineDraw, components.CreditLineRepayment:
+			balanceSheet.CreditLineBalance -= transaction.Amount
+		case components.LoanProceeds, components.LoanPrincipal:
+			balanceSheet.LoanBalances -= transaction.Amount
+		case components.SecurityDeposit, components.DepositRefund:
+			balanceSheet.SecurityDeposits -= transaction.Amount
+		}
+	}
+}
+
+func monthStart(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+}
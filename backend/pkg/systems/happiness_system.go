@@ -0,0 +1,157 @@
+package systems
+
+import (
+	"math"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/*
+===========================================================
+
+	Happiness system
+
+===========================================================
+
+1. **Monthly Update**
+  - At the start of every month, and when they move in, each tenant's happiness is recomputed on a 0-100 scale
+    from a base of 70 plus the points of each factor:
+  - *Rent:* Paying less than the rent they desire raises happiness by up to 15 points; paying more lowers it,
+    6 points for every 10% over, down to -30.
+  - *Condition:* Half a point for every point of condition above 80, and half a point off for every point below.
+  - *Upgrades:* 2 points for every completed upgrade, up to 10.
+  - *Neighborhood:* Desirable neighborhoods and neighborhoods with many upgraded properties make tenants happier.
+
+2. **Effects**
+  - *Move-Outs:* Tenants at 70 move out with their standard chance. Happier tenants are up to four times less likely
+    to break their lease and unhappy ones up to three times more likely. The chance of renewing a lease scales the same way.
+  - *Payments:* Unhappy tenants are more likely to miss or part-pay their rent, and happy ones less likely.
+
+===========================================================
+*/
+type HappinessSystem struct{}
+
+const (
+	baseHappiness                = 70.0
+	maxUnderpaidRentHappiness    = 15.0
+	maxOverpaidRentUnhappiness   = 30.0
+	rentHappinessPerPercent      = 0.6
+	idealCondition               = 80.0
+	happinessPerUpgrade          = 2.0
+	maxUpgradeHappiness          = 10.0
+	desirabilityHappiness        = 0.3  // Points per point of desirability above average
+	neighborhoodUpgradeHappiness = 10.0 // Points when every property in the neighborhood is upgraded
+	maxRenewalChance             = 0.95
+)
+
+func (s *HappinessSystem) Update(world *ecs.World) {
+	gameTime, _ := world.GetCurrentGameTime()
+	if gameTime.IsPaused {
+		return
+	}
+
+	for _, property := range world.GetAllProperties() {
+		rentable, err := property.GetRentable()
+		if err != nil {
+			continue
+		}
+		for _, unit := range rentable.Units {
+			tenant := unit.Tenant
+			if tenant == nil {
+				continue
+			}
+			if tenant.Happiness.LastUpdated.IsZero() || !nextMonthStart(tenant.Happiness.LastUpdated).After(gameTime.CurrentDate) {
+				updateTenantHappiness(world, property, unit, gameTime.CurrentDate)
+			}
+		}
+	}
+}
+
+// updateTenantHappiness recomputes the happiness of the unit's tenant and the move-out chance that follows from it.
+func updateTenantHappiness(world *ecs.World, property *ecs.Entity, unit *components.Unit, date time.Time) {
+	tenant := unit.Tenant
+	factors := map[string]float64{
+		"Rent":         rentHappiness(world, property, unit, date),
+		"Condition":    conditionHappiness(property),
+		"Upgrades":     upgradeHappiness(property, date),
+		"Neighborhood": neighborhoodHappiness(world, property),
+	}
+
+	value := baseHappiness
+	for _, points := range factors {
+		value += points
+	}
+	tenant.Happiness = components.Happiness{
+		Value:       math.Max(0, math.Min(100, value)),
+		Factors:     factors,
+		LastUpdated: date,
+	}
+
+	tenant.MoveOutChance = 0
+	if tenant.Lease == nil || tenant.Lease.Terms().Breakable {
+		tenant.MoveOutChance = components.MonthlyMoveOutChance * happinessRiskMultiplier(tenant)
+	}
+}
+
+// happinessRiskMultiplier scales the chance of a tenant moving out or missing rent: 1 at the base happiness,
+// falling to 0.25 for the happiest tenants and rising to 3 for the unhappiest.
+func happinessRiskMultiplier(tenant *components.Tenant) float64 {
+	value := tenant.Happiness.Value
+	if value >= baseHappiness {
+		return 1 - 0.75*(value-baseHappiness)/(100-baseHappiness)
+	}
+	return 1 + 2*(baseHappiness-value)/baseHappiness
+}
+
+// renewalChance is the chance the tenant renews their lease, scaled by how happy they are.
+func renewalChance(tenant *components.Tenant) float64 {
+	return math.Min(maxRenewalChance, tenant.Lease.Terms().RenewalChance/happinessRiskMultiplier(tenant))
+}
+
+func rentHappiness(world *ecs.World, property *ecs.Entity, unit *components.Unit, date time.Time) float64 {
+	tenant := unit.Tenant
+	desiredRent := UnitDesiredRent(world, property, unit, date)
+	if tenant.Lease == nil || desiredRent <= 0 {
+		return 0
+	}
+	percentOver := 100 * (tenant.Lease.RentOn(date)/desiredRent - 1)
+	return math.Max(-maxOverpaidRentUnhappiness, math.Min(maxUnderpaidRentHappiness, -rentHappinessPerPercent*percentOver))
+}
+
+func conditionHappiness(property *ecs.Entity) float64 {
+	maintainable, err := property.GetMaintainable()
+	if err != nil {
+		return 0
+	}
+	return (maintainable.Condition - idealCondition) / 2
+}
+
+func upgradeHappiness(property *ecs.Entity, date time.Time) float64 {
+	upgradable, err := property.GetUpgradable()
+	if err != nil {
+		return 0
+	}
+	completed := 0
+	for _, upgrade := range upgradable.AppliedUpgrades {
+		if isUpgradeComplete(upgrade, date) {
+			completed++
+		}
+	}
+	return math.Min(maxUpgradeHappiness, happinessPerUpgrade*float64(completed))
+}
+
+func neighborhoodHappiness(world *ecs.World, property *ecs.Entity) float64 {
+	groupable, err := property.GetGroupable()
+	if err != nil {
+		return 0
+	}
+	points := neighborhoodUpgradeHappiness * world.GroupUpgradedPercentages[groupable.GroupID] / 100
+	if neighborhood := world.GetNeighborhood(groupable.GroupID); neighborhood != nil {
+		if desirability, err := neighborhood.GetDesirability(); err == nil {
+			points += desirabilityHappiness * (desirability.Value - averageDesirability)
+		}
+	}
+	return points
+}
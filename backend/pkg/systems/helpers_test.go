@@ -0,0 +1,55 @@
+package systems
+
+import (
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+	"github.com/markbmullins/city-developer/pkg/entities"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// newTestWorld creates a world on the given date with the singleton entities the systems look up, and no players or properties.
+func newTestWorld(today time.Time) *ecs.World {
+	world := ecs.NewWorld()
+	world.AddSpecificEntity(0, entities.CreateGameTime(today, 1))
+	world.AddEntity(entities.CreateEconomy(today))
+	world.AddEntity(entities.CreateCentralBank(today))
+	world.AddEntity(entities.CreateInflation(today))
+	world.AddEntity(entities.CreateAuctionHouse(today))
+	return world
+}
+
+func addTestPlayer(world *ecs.World, funds float64) *ecs.Entity {
+	player := entities.CreatePlayer("Player", funds)
+	world.AddEntity(player)
+	return player
+}
+
+// addTestProperty adds a single-family house bought outright by the owner on the purchase date.
+func addTestProperty(world *ecs.World, owner *ecs.Entity, price, rent float64, purchaseDate time.Time) *ecs.Entity {
+	property := entities.CreateProperty("House", "1 Main St", "", components.Residential, components.SingleFamily, rent, price, 0)
+	world.AddEntity(property)
+	ownable, _ := property.GetOwnable()
+	ownable.Owned = true
+	ownable.OwnerID = owner.ID
+	purchaseable, _ := property.GetPurchaseable()
+	purchaseable.PurchaseDate = purchaseDate
+	world.BuyProperty(property.ID, owner.ID)
+	return property
+}
+
+// ledgerTotal sums the player's transactions in the category for the property.
+func ledgerTotal(player *ecs.Entity, category components.TransactionCategory, propertyID int) float64 {
+	ledger, _ := player.GetLedger()
+	total := 0.0
+	for _, transaction := range ledger.Transactions {
+		if transaction.Category == category && transaction.PropertyID == propertyID {
+			total += transaction.Amount
+		}
+	}
+	return total
+}
@@ -0,0 +1,67 @@
+package systems
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/*
+===========================================================
+
+	Inflation system
+
+===========================================================
+
+1. **Yearly Compounding**
+  - On the first update of each year the inflation index compounds by the year's annual rate.
+  - The rate for the coming year is then set from the current economic phase.
+
+2. **Effects**
+  - Standard rents, including upgrade rent increases, are multiplied by the index. Owner-set asking rents are not.
+  - Upgrade costs and fixed operating expenses such as utilities are multiplied by the index.
+  - Property values, and with them purchase prices, insurance, maintenance costs and property taxes, are scaled by the index.
+
+3. **Reports**
+  - Each closed financial statement records the index, so statements can be shown in nominal
+    or real (start of game) dollars.
+
+===========================================================
+*/
+type InflationSystem struct{}
+
+func (s *InflationSystem) Update(world *ecs.World) {
+	gameTime, _ := world.GetCurrentGameTime()
+	if gameTime.IsPaused {
+		return
+	}
+	inflation, err := world.GetInflation()
+	if err != nil {
+		return
+	}
+
+	for !nextYearStart(inflation.LastUpdated).After(gameTime.CurrentDate) {
+		inflation.Index *= 1 + inflation.AnnualRate
+		if economy, err := world.GetEconomy(); err == nil {
+			if rate, ok := inflation.PhaseRates[economy.Phase]; ok {
+				inflation.AnnualRate = rate
+			}
+		}
+		inflation.LastUpdated = nextYearStart(inflation.LastUpdated)
+		fmt.Printf("Inflation index is now %.4f, next year's rate %.2f%%\n", inflation.Index, inflation.AnnualRate*100)
+	}
+}
+
+// InflationIndex returns the cumulative inflation since the start of the game, 1.0 without inflation.
+func InflationIndex(world *ecs.World) float64 {
+	inflation, err := world.GetInflation()
+	if err != nil {
+		return 1
+	}
+	return inflation.Index
+}
+
+func nextYearStart(date time.Time) time.Time {
+	return time.Date(date.Year()+1, 1, 1, 0, 0, 0, 0, date.Location())
+}
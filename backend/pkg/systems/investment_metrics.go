@@ -0,0 +1,280 @@
+package systems
+
+import (
+	"math"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/*
+===========================================================
+
+	Investment metrics
+
+===========================================================
+
+1. **Owned Properties**
+  - Computed from the owner's ledger since the purchase date: purchase price, upgrade spend, mortgage proceeds,
+    rent, late fees and lease break fees collected, operating expenses (including maintenance and property tax) and debt service.
+  - *Cash Invested:* Purchase price plus upgrade spend less the mortgage borrowed.
+  - *Net Operating Income:* Rent collected less operating expenses, annualized over the months held.
+  - *Cap Rate:* Annual net operating income over the current market value.
+  - *Cash-on-Cash Return:* Annual cash flow after debt service over the cash invested.
+  - *ROI:* Cash flow to date plus the equity that would be released by selling today, less the cash invested, over the cash invested.
+  - *Payback Period:* Years of the current annual cash flow needed to recover the cash invested.
+
+2. **Properties For Sale**
+  - Projected for an all-cash purchase at market value from the rent tenants would pay today weighted by the expected occupancy,
+    a year of operating expenses and the property tax at the neighborhood's millage rate.
+  - ROI is zero since nothing has been collected yet.
+
+3. **Portfolios**
+  - The player's share of each owned property's metrics, summed per player, with the ratios recomputed from the totals.
+  - Co-owned properties are measured from the ledgers of all their shareholders.
+
+The payback period is omitted when a property does not produce positive cash flow.
+
+===========================================================
+*/
+
+const averageDaysPerMonth = 365.25 / 12
+
+type PropertyMetrics struct {
+	PropertyID         int             `json:"property_id"`
+	Name               string          `json:"name"`
+	GroupID            int             `json:"group_id"`
+	Neighborhood       string          `json:"neighborhood"`
+	Type               string          `json:"type"`
+	Subtype            string          `json:"subtype"`
+	Owned              bool            `json:"owned"`
+	Units              int             `json:"units"`
+	OccupiedUnits      int             `json:"occupied_units"`
+	OwnerID            int             `json:"owner_id"`
+	Shareholders       map[int]float64 `json:"shareholders"` // Player ID -> percentage owned
+	Projected          bool            `json:"projected"`    // True for properties for sale, whose metrics are projections
+	MonthsHeld         float64         `json:"months_held"`
+	PurchasePrice      float64         `json:"purchase_price"`
+	UpgradeSpend       float64         `json:"upgrade_spend"`
+	TotalInvestment    float64         `json:"total_investment"`
+	CashInvested       float64         `json:"cash_invested"`
+	MarketValue        float64         `json:"market_value"`
+	Equity             float64         `json:"equity"` // Sale price less any outstanding mortgage
+	RentCollected      float64         `json:"rent_collected"`
+	OperatingExpenses  float64         `json:"operating_expenses"`
+	NetOperatingIncome float64         `json:"net_operating_income"`
+	DebtService        float64         `json:"debt_service"`
+	CashFlow           float64         `json:"cash_flow"`
+	AnnualNOI          float64         `json:"annual_noi"`
+	AnnualCashFlow     float64         `json:"annual_cash_flow"`
+	CapRate            float64         `json:"cap_rate"`
+	CashOnCashReturn   float64         `json:"cash_on_cash_return"`
+	ROI                float64         `json:"roi"`
+	PaybackYears       *float64        `json:"payback_years"`
+}
+
+type PortfolioMetrics struct {
+	PlayerID           int      `json:"player_id"`
+	Properties         int      `json:"properties"`
+	Units              int      `json:"units"`
+	OccupiedUnits      int      `json:"occupied_units"` // Units with a tenant
+	TotalInvestment    float64  `json:"total_investment"`
+	CashInvested       float64  `json:"cash_invested"`
+	MarketValue        float64  `json:"market_value"`
+	Equity             float64  `json:"equity"`
+	RentCollected      float64  `json:"rent_collected"`
+	OperatingExpenses  float64  `json:"operating_expenses"`
+	NetOperatingIncome float64  `json:"net_operating_income"`
+	DebtService        float64  `json:"debt_service"`
+	CashFlow           float64  `json:"cash_flow"`
+	AnnualNOI          float64  `json:"annual_noi"`
+	AnnualCashFlow     float64  `json:"annual_cash_flow"`
+	CapRate            float64  `json:"cap_rate"`
+	CashOnCashReturn   float64  `json:"cash_on_cash_return"`
+	ROI                float64  `json:"roi"`
+	PaybackYears       *float64 `json:"payback_years"`
+}
+
+// Ledger categories counted as operating expenses of a property
+var propertyOperatingExpenseCategories = map[components.TransactionCategory]bool{
+	components.Utilities:        true,
+	components.Insurance:        true,
+	components.ManagementFees:   true,
+	components.ScreeningFees:    true,
+	components.VacancyReserve:   true,
+	components.MaintenanceSpend: true,
+	components.PropertyTax:      true,
+}
+
+// CalculatePropertyMetrics returns the investment metrics of a property, from its owner's ledger if it is owned
+// or projected for an all-cash purchase otherwise.
+func CalculatePropertyMetrics(world *ecs.World, property *ecs.Entity) *PropertyMetrics {
+	metrics := &PropertyMetrics{
+		PropertyID:  property.ID,
+		MarketValue: PropertyValue(property),
+	}
+	if information, err := property.GetInformation(); err == nil {
+		metrics.Name = information.Name
+	}
+	if groupable, err := property.GetGroupable(); err == nil {
+		metrics.GroupID = groupable.GroupID
+		if neighborhood := world.GetNeighborhood(groupable.GroupID); neighborhood != nil {
+			if information, err := neighborhood.GetInformation(); err == nil {
+				metrics.Neighborhood = information.Name
+			}
+		}
+	}
+	if classifiable, err := property.GetClassifiable(); err == nil {
+		metrics.Type = string(classifiable.Type)
+		metrics.Subtype = string(classifiable.Subtype)
+	}
+	if rentable, err := property.GetRentable(); err == nil {
+		metrics.Units = len(rentable.Units)
+	}
+
+	gameTime, _ := world.GetCurrentGameTime()
+	ownable, _ := property.GetOwnable()
+	if ownable != nil && ownable.Owned {
+		metrics.Owned = true
+		metrics.OwnerID = ownable.OwnerID
+		metrics.Shareholders = ownable.Shareholders()
+		if rentable, err := property.GetRentable(); err == nil {
+			metrics.OccupiedUnits = rentable.OccupiedUnits()
+		}
+		calculateOwnedMetrics(world, property, metrics, gameTime.CurrentDate)
+	} else {
+		projectMetrics(world, property, metrics, gameTime.CurrentDate)
+	}
+
+	metrics.TotalInvestment = metrics.PurchasePrice + metrics.UpgradeSpend
+	metrics.CapRate = ratio(metrics.AnnualNOI, metrics.MarketValue)
+	metrics.CashOnCashReturn = ratio(metrics.AnnualCashFlow, metrics.CashInvested)
+	metrics.PaybackYears = paybackYears(metrics.CashInvested, metrics.AnnualCashFlow)
+	return metrics
+}
+
+func calculateOwnedMetrics(world *ecs.World, property *ecs.Entity, metrics *PropertyMetrics, now time.Time) {
+	purchaseable, _ := property.GetPurchaseable()
+	metrics.PurchasePrice = purchaseable.Cost
+	metrics.MonthsHeld = now.Sub(purchaseable.PurchaseDate).Hours() / 24 / averageDaysPerMonth
+
+	// Income and costs are split between the shareholders, so their ledgers together hold the property's totals
+	loanProceeds := 0.0
+	for _, playerID := range SortedShareholderIDs(metrics.Shareholders) {
+		player := world.GetEntity(playerID)
+		if player == nil {
+			continue
+		}
+		ledger, err := player.GetLedger()
+		if err != nil {
+			continue
+		}
+		for _, transaction := range ledger.Transactions {
+			if transaction.PropertyID != property.ID || transaction.Date.Before(purchaseable.PurchaseDate) {
+				continue
+			}
+			switch {
+			case transaction.Category == components.RentIncome || transaction.Category == components.LateFeeIncome || transaction.Category == components.LeaseBreakFee:
+				metrics.RentCollected += transaction.Amount
+			case propertyOperatingExpenseCategories[transaction.Category]:
+				metrics.OperatingExpenses -= transaction.Amount
+			case transaction.Category == components.LoanInterest || transaction.Category == components.LoanPrincipal:
+				metrics.DebtService -= transaction.Amount
+			case transaction.Category == components.UpgradeSpend:
+				metrics.UpgradeSpend -= transaction.Amount
+			case transaction.Category == components.LoanProceeds:
+				loanProceeds += transaction.Amount
+			}
+		}
+	}
+
+	metrics.CashInvested = metrics.PurchasePrice + metrics.UpgradeSpend - loanProceeds
+	metrics.NetOperatingIncome = metrics.RentCollected - metrics.OperatingExpenses
+	metrics.CashFlow = metrics.NetOperatingIncome - metrics.DebtService
+
+	// Annualize over at least a month so a fresh purchase doesn't extrapolate a few days of rent
+	yearsHeld := math.Max(metrics.MonthsHeld, 1) / 12
+	metrics.AnnualNOI = metrics.NetOperatingIncome / yearsHeld
+	metrics.AnnualCashFlow = metrics.CashFlow / yearsHeld
+
+	metrics.Equity = SalePrice(property)
+	if loan, err := property.GetLoan(); err == nil {
+		metrics.Equity -= loan.OutstandingPrincipal
+	}
+	metrics.ROI = ratio(metrics.CashFlow+metrics.Equity-metrics.CashInvested, metrics.CashInvested)
+}
+
+func projectMetrics(world *ecs.World, property *ecs.Entity, metrics *PropertyMetrics, now time.Time) {
+	metrics.Projected = true
+	metrics.PurchasePrice = metrics.MarketValue
+	metrics.CashInvested = metrics.MarketValue
+	metrics.Equity = SalePrice(property)
+
+	annualRent := 0.0
+	if rentable, err := property.GetRentable(); err == nil {
+		for _, unit := range rentable.Units {
+			annualRent += UnitDesiredRent(world, property, unit, now) * expectedOccupancy(world, property, unit, now) * 12
+		}
+	}
+	annualExpenses := 0.0
+	if operatingExpenses, err := property.GetOperatingExpenses(); err == nil {
+		rates := operatingExpenses.Rates
+		annualExpenses += monthlyUtilities(property, rates) * InflationIndex(world) * 12
+		annualExpenses += metrics.MarketValue * rates.AnnualInsuranceRate / 100
+		annualExpenses += annualRent * (rates.ManagementFeePercentage + rates.VacancyReservePercentage) / 100
+	}
+	groupable, _ := property.GetGroupable()
+	if neighborhood := world.GetNeighborhood(groupable.GroupID); neighborhood != nil {
+		if district, err := neighborhood.GetTaxDistrict(); err == nil {
+			annualExpenses += metrics.MarketValue * district.MillageRate / 1000
+		}
+	}
+
+	metrics.AnnualNOI = annualRent - annualExpenses
+	metrics.AnnualCashFlow = metrics.AnnualNOI
+}
+
+// CalculatePortfolioMetrics sums the player's share of the metrics of every property they own.
+func CalculatePortfolioMetrics(world *ecs.World, player *ecs.Entity) *PortfolioMetrics {
+	portfolio := &PortfolioMetrics{PlayerID: player.ID}
+	for _, property := range PropertiesHeldBy(world, player.ID) {
+		metrics := CalculatePropertyMetrics(world, property)
+		share := ownershipFraction(property, player.ID)
+		portfolio.Properties++
+		portfolio.Units += metrics.Units
+		portfolio.OccupiedUnits += metrics.OccupiedUnits
+		portfolio.TotalInvestment += metrics.TotalInvestment * share
+		portfolio.CashInvested += metrics.CashInvested * share
+		portfolio.MarketValue += metrics.MarketValue * share
+		portfolio.Equity += metrics.Equity * share
+		portfolio.RentCollected += metrics.RentCollected * share
+		portfolio.OperatingExpenses += metrics.OperatingExpenses * share
+		portfolio.NetOperatingIncome += metrics.NetOperatingIncome * share
+		portfolio.DebtService += metrics.DebtService * share
+		portfolio.CashFlow += metrics.CashFlow * share
+		portfolio.AnnualNOI += metrics.AnnualNOI * share
+		portfolio.AnnualCashFlow += metrics.AnnualCashFlow * share
+	}
+
+	portfolio.CapRate = ratio(portfolio.AnnualNOI, portfolio.MarketValue)
+	portfolio.CashOnCashReturn = ratio(portfolio.AnnualCashFlow, portfolio.CashInvested)
+	portfolio.ROI = ratio(portfolio.CashFlow+portfolio.Equity-portfolio.CashInvested, portfolio.CashInvested)
+	portfolio.PaybackYears = paybackYears(portfolio.CashInvested, portfolio.AnnualCashFlow)
+	return portfolio
+}
+
+func ratio(numerator, denominator float64) float64 {
+	if denominator <= 0 {
+		return 0
+	}
+	return numerator / denominator
+}
+
+func paybackYears(cashInvested, annualCashFlow float64) *float64 {
+	if annualCashFlow <= 0 {
+		return nil
+	}
+	years := cashInvested / annualCashFlow
+	return &years
+}
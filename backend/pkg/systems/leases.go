@@ -0,0 +1,130 @@
+package systems
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+	"github.com/markbmullins/city-developer/pkg/entities"
+)
+
+/*
+===========================================================
+
+	Leases
+
+===========================================================
+
+1. **Signing**
+  - Every tenant signs a lease when they move in, at the rent the property was advertised at when they applied:
+    the owner's asking rent or the standard rent.
+  - *Residential:* Six months, one or two years as the tenant asked for, with the rent fixed for the term.
+  - *Commercial:* Three, five or ten years as the tenant asked for, with the rent escalating 3% on every anniversary of the lease.
+    Retail, restaurant, service and entertainment leases also charge a percentage of the tenant's sales above a breakpoint
+    (see business_system.go).
+
+2. **Rent**
+  - The rent locked in the lease, with any escalations, is charged every month and prorated for the month the tenant moves in.
+  - No rent is charged under a lease from the day it ends. In the month a lease is renewed, the days before the renewal
+    are charged at the old lease's rent and the rest at the renewed rent.
+  - Changes to the standard rent, completed upgrades and new asking rents don't affect a lease until it is renewed.
+
+3. **Renewal**
+  - When a lease ends the owner offers to renew at the advertised rent.
+  - Tenants renew with the renewal chance for their lease type, 60% residential and 75% commercial, scaled by their
+    happiness (see happiness_system.go), and otherwise move out on the day the lease ends, paying rent up to then
+    (see tenant_payments.go).
+  - Tenants negotiate increases above 5% (residential) or 10% (commercial) down to that cap,
+    and renew at the lower rent if the market has fallen.
+  - Renewed residential leases run for another year and commercial leases for three years.
+
+4. **Breaking a Lease**
+  - Residential tenants may break their lease at the start of a month with their move-out chance, paying a month's rent as a fee.
+  - Commercial leases can't be broken; commercial tenants only leave when their lease ends, they are evicted
+    or their business closes (see business_system.go).
+
+===========================================================
+*/
+
+// leaseRent is the rent due for the month under the tenant's lease and the lease it renewed,
+// prorated by the days the tenant lived in the property while each lease ran.
+func leaseRent(property *ecs.Entity, tenant *components.Tenant, monthStart, monthEnd time.Time) float64 {
+	rent := 0.0
+	for _, lease := range []*components.Lease{tenant.PreviousLease, tenant.Lease} {
+		rent += leasePeriodRent(property, tenant, lease, monthStart, monthEnd)
+	}
+	return roundToNearest5(rent)
+}
+
+// leasePeriodRent is the unrounded rent due under the lease for the days of the month it ran and the tenant lived in the property.
+// Nothing is due from the day the lease ends.
+func leasePeriodRent(property *ecs.Entity, tenant *components.Tenant, lease *components.Lease, monthStart, monthEnd time.Time) float64 {
+	if lease == nil {
+		return 0
+	}
+	purchaseable, _ := property.GetPurchaseable()
+	start := maxTime(maxTime(maxTime(purchaseable.PurchaseDate.AddDate(0, 0, 1), monthStart), tenant.MoveInDate), lease.StartDate)
+	end := monthEnd
+	if leaseLastDay := lease.EndDate.AddDate(0, 0, -1); leaseLastDay.Before(end) {
+		end = leaseLastDay
+	}
+	days := countDaysInRange(start, end)
+	if days == 0 {
+		return 0
+	}
+	return lease.RentOn(start) * float64(days) / float64(daysInMonth(monthStart))
+}
+
+// renewLease negotiates a new lease with the unit's tenant when their lease ends. Tenants who don't renew move out,
+// in which case it returns false.
+func renewLease(world *ecs.World, property *ecs.Entity, unit *components.Unit, date time.Time) bool {
+	tenant := unit.Tenant
+	lease := tenant.Lease
+	terms := lease.Terms()
+	if rand.Float64() >= renewalChance(tenant) {
+		fmt.Printf("Tenant did not renew their lease on property ID %d unit %d\n", property.ID, unit.Number)
+		moveOutTenant(world, property, unit, lease.EndDate)
+		return false
+	}
+
+	currentRent := lease.RentOn(lease.EndDate.AddDate(0, 0, -1))
+	offeredRent := MarketAskingRent(world, property, unit, date)
+	rent := roundToNearest5(math.Min(offeredRent, currentRent*(1+terms.MaxRenewalIncreasePercentage/100)))
+
+	renewal := entities.CreateLease(lease.Type, rent, lease.EndDate, terms.RenewalTermMonths)
+	renewal.Renewals = lease.Renewals + 1
+	renewal.PercentageRent = lease.PercentageRent
+	tenant.PreviousLease = lease
+	tenant.Lease = renewal
+	chargeRenewalCatchUp(world, property, tenant, date)
+	fmt.Printf("Tenant renewed their lease on property ID %d unit %d at %.2f (was %.2f)\n", property.ID, unit.Number, rent, currentRent)
+	return true
+}
+
+// chargeRenewalCatchUp charges the renewed lease's rent for the days since it started in months that rent collection
+// has already charged, when time moved on past the end of the lease before it was renewed.
+func chargeRenewalCatchUp(world *ecs.World, property *ecs.Entity, tenant *components.Tenant, date time.Time) {
+	rent := 0.0
+	for month := monthStart(tenant.Lease.StartDate); month.Before(monthStart(date)); month = month.AddDate(0, 1, 0) {
+		rent += leasePeriodRent(property, tenant, tenant.Lease, month, monthEnd(month))
+	}
+	if rent = roundToNearest5(rent); rent <= 0 {
+		return
+	}
+	tenant.RentDue += rent
+	receiveTenantPayment(world, property, tenant, date)
+}
+
+// breakLease charges the unit's tenant their early termination fee for the owners and moves the tenant out.
+func breakLease(world *ecs.World, property *ecs.Entity, unit *components.Unit, date time.Time) {
+	if lease := unit.Tenant.Lease; lease != nil {
+		if fee := roundToNearest5(lease.RentOn(date) * lease.Terms().EarlyTerminationFeeMonths); fee > 0 {
+			TransferFunds(world, components.ExternalParty, property.ID, date, components.LeaseBreakFee, fee, property.ID, "Lease break fee")
+		}
+	}
+	fmt.Printf("Tenant broke their lease and moved out of property ID %d unit %d\n", property.ID, unit.Number)
+	moveOutTenant(world, property, unit, date)
+}
@@ -0,0 +1,72 @@
+package systems
+
+import (
+	"testing"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/entities"
+)
+
+func TestLeaseRent(t *testing.T) {
+	moveIn := date(2024, time.March, 15)
+	renewed := func() *components.Tenant {
+		tenant := entities.CreateTenant(components.Residential, 1000, 1000, 12, 1, moveIn)
+		tenant.PreviousLease = tenant.Lease
+		tenant.Lease = entities.CreateLease(components.Residential, 1100, tenant.PreviousLease.EndDate, 12)
+		return tenant
+	}
+
+	tests := []struct {
+		name   string
+		tenant *components.Tenant
+		month  time.Time
+		want   float64
+	}{
+		{
+			name:   "full month",
+			tenant: entities.CreateTenant(components.Residential, 1000, 1000, 12, 1, moveIn),
+			month:  date(2024, time.April, 1),
+			want:   1000,
+		},
+		{
+			name:   "prorated from the move-in day",
+			tenant: entities.CreateTenant(components.Residential, 1000, 1000, 12, 1, moveIn),
+			month:  date(2024, time.March, 1),
+			want:   545, // 17 of 31 days
+		},
+		{
+			name:   "nothing due from the day the lease ends",
+			tenant: entities.CreateTenant(components.Residential, 1000, 1000, 12, 1, moveIn),
+			month:  date(2025, time.March, 1),
+			want:   450, // 14 of 31 days
+		},
+		{
+			name:   "nothing due after the lease has ended",
+			tenant: entities.CreateTenant(components.Residential, 1000, 1000, 12, 1, moveIn),
+			month:  date(2025, time.April, 1),
+			want:   0,
+		},
+		{
+			name:   "renewed partway through the month",
+			tenant: renewed(),
+			month:  date(2025, time.March, 1),
+			want:   1050, // 14 days at 1000 and 17 days at 1100
+		},
+		{
+			name:   "first full month of the renewal",
+			tenant: renewed(),
+			month:  date(2025, time.April, 1),
+			want:   1100,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			property := entities.CreateProperty("House", "1 Main St", "", components.Residential, components.SingleFamily, 1000, 100000, 0)
+			if got := leaseRent(property, test.tenant, test.month, monthEnd(test.month)); got != test.want {
+				t.Errorf("leaseRent() = %.2f, want %.2f", got, test.want)
+			}
+		})
+	}
+}
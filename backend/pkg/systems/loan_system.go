@@ -0,0 +1,84 @@
+package systems
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/*
+===========================================================
+
+	Loans
+
+===========================================================
+
+- Loans are attached to the property they finance.
+- Each loan is paid by a monthly recurring payment from the borrower (see recurring_payment_system.go),
+  due on the first of every month, starting the month after purchase.
+- Each payment covers one month of interest on the outstanding principal; the remainder pays down principal.
+- The final payment is capped at the remaining principal plus interest.
+- Payments are always debited, even if they push the borrower's funds below zero (see bankruptcy_system.go).
+- Missed ticks (fast-forwarding) are caught up by processing every due payment in order.
+- Loans and their payments are removed once the outstanding principal reaches zero, or when the property is sold.
+
+===========================================================
+*/
+
+// payMortgage makes the monthly payment on the mortgage a recurring payment is set up for,
+// ending the payment once the loan is paid off or no longer there.
+func payMortgage(world *ecs.World, payment *components.RecurringPayment, date time.Time) {
+	property := world.GetEntity(payment.PropertyID)
+	if property == nil {
+		payment.Cancelled = true
+		return
+	}
+	loan, err := property.GetLoan()
+	if err != nil || loan.BorrowerID != payment.PayerID {
+		payment.Cancelled = true
+		return
+	}
+
+	paid := makeLoanPayment(world, property, loan, date)
+	payment.PaymentsMade++
+	payment.TotalPaid += paid
+
+	if loan.IsPaidOff() {
+		world.RemoveLoanFromProperty(property)
+		payment.Cancelled = true
+		fmt.Printf("Loan on property ID %d paid off\n", property.ID)
+	}
+}
+
+// makeLoanPayment debits the month's interest and principal from the borrower and returns the amount paid.
+func makeLoanPayment(world *ecs.World, property *ecs.Entity, loan *components.Loan, date time.Time) float64 {
+	interest := loan.NextInterest()
+	payment := math.Min(loan.MonthlyPayment, loan.OutstandingPrincipal+interest)
+	principalPaid := payment - interest
+
+	borrower := world.GetEntity(loan.BorrowerID)
+	if borrower == nil {
+		return 0
+	}
+	borrower.PostTransaction(date, components.LoanInterest, -interest, property.ID, "Mortgage interest")
+	borrower.PostTransaction(date, components.LoanPrincipal, -principalPaid, property.ID, "Mortgage principal")
+
+	loan.OutstandingPrincipal -= principalPaid
+	loan.TotalInterestPaid += interest
+	loan.PaymentsMade++
+	loan.NextPaymentDate = date.AddDate(0, 1, 0)
+	fmt.Printf("Loan payment of %.2f (interest %.2f) debited from player ID %d for property ID %d\n", payment, interest, borrower.ID, property.ID)
+	return payment
+}
+
+// cancelMortgagePayments removes the recurring payments set up for the mortgage on the property.
+func cancelMortgagePayments(world *ecs.World, propertyID int) {
+	for _, entity := range world.QueryByComponent("RecurringPayment") {
+		if payment, err := entity.GetRecurringPayment(); err == nil && payment.Mortgage && payment.PropertyID == propertyID {
+			world.RemoveEntity(entity.ID)
+		}
+	}
+}
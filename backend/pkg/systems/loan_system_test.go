@@ -0,0 +1,154 @@
+package systems
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+	"github.com/markbmullins/city-developer/pkg/entities"
+)
+
+// addTestMortgage finances the property with a loan to its owner, paid by a monthly recurring payment.
+func addTestMortgage(world *ecs.World, property *ecs.Entity, borrower *ecs.Entity, principal, annualRate float64, termMonths int, startDate time.Time) *components.Loan {
+	loan := entities.CreateLoan(borrower.ID, principal, annualRate, termMonths, startDate)
+	world.AddLoanToProperty(property, loan)
+	world.AddEntity(entities.CreateMortgagePayment(property.ID, loan))
+	return loan
+}
+
+func mortgagePaymentCount(world *ecs.World) int {
+	count := 0
+	for _, entity := range world.QueryByComponent("RecurringPayment") {
+		if payment, err := entity.GetRecurringPayment(); err == nil && payment.Mortgage {
+			count++
+		}
+	}
+	return count
+}
+
+// remainingPrincipal is the balance of an amortized loan after the given number of payments.
+func remainingPrincipal(principal, annualRate float64, termMonths, payments int) float64 {
+	rate := annualRate / 12
+	growth := math.Pow(1+rate, float64(payments))
+	return principal*growth - components.AmortizedPayment(principal, annualRate, termMonths)*(growth-1)/rate
+}
+
+func TestMortgageAmortization(t *testing.T) {
+	tests := []struct {
+		name         string
+		termMonths   int
+		updates      []time.Time // Dates the recurring payment system runs on
+		wantPayments int
+		wantPaidOff  bool
+	}{
+		{
+			name:         "nothing due in the month of purchase",
+			termMonths:   12,
+			updates:      []time.Time{date(2024, time.January, 31)},
+			wantPayments: 0,
+		},
+		{
+			name:         "first payment on the first of the next month",
+			termMonths:   12,
+			updates:      []time.Time{date(2024, time.February, 1)},
+			wantPayments: 1,
+		},
+		{
+			name:         "monthly payments",
+			termMonths:   12,
+			updates:      []time.Time{date(2024, time.February, 1), date(2024, time.March, 1), date(2024, time.April, 1)},
+			wantPayments: 3,
+		},
+		{
+			name:         "missed months are caught up",
+			termMonths:   12,
+			updates:      []time.Time{date(2024, time.July, 15)},
+			wantPayments: 6,
+		},
+		{
+			name:         "paid off at the end of the term",
+			termMonths:   12,
+			updates:      []time.Time{date(2025, time.June, 1)},
+			wantPayments: 12,
+			wantPaidOff:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			world := newTestWorld(date(2024, time.January, 15))
+			borrower := addTestPlayer(world, 0)
+			property := addTestProperty(world, borrower, 150000, 1000, date(2024, time.January, 15))
+			loan := addTestMortgage(world, property, borrower, 120000, 0.06, test.termMonths, date(2024, time.January, 15))
+
+			gameTime, _ := world.GetCurrentGameTime()
+			for _, update := range test.updates {
+				gameTime.CurrentDate = update
+				(&RecurringPaymentSystem{}).Update(world)
+			}
+
+			if loan.PaymentsMade != test.wantPayments {
+				t.Errorf("payments made = %d, want %d", loan.PaymentsMade, test.wantPayments)
+			}
+			want := remainingPrincipal(120000, 0.06, test.termMonths, test.wantPayments)
+			if math.Abs(loan.OutstandingPrincipal-want) > 0.01 {
+				t.Errorf("outstanding principal = %.2f, want %.2f", loan.OutstandingPrincipal, want)
+			}
+			principalPaid := -ledgerTotal(borrower, components.LoanPrincipal, property.ID)
+			if math.Abs(principalPaid-(120000-want)) > 0.01 {
+				t.Errorf("principal paid = %.2f, want %.2f", principalPaid, 120000-want)
+			}
+			interestPaid := -ledgerTotal(borrower, components.LoanInterest, property.ID)
+			if math.Abs(interestPaid-loan.TotalInterestPaid) > 0.01 {
+				t.Errorf("interest posted = %.2f, want %.2f", interestPaid, loan.TotalInterestPaid)
+			}
+
+			_, err := property.GetLoan()
+			if paidOff := err != nil; paidOff != test.wantPaidOff {
+				t.Errorf("loan removed = %v, want %v", paidOff, test.wantPaidOff)
+			}
+			if paidOff := mortgagePaymentCount(world) == 0; paidOff != test.wantPaidOff {
+				t.Errorf("mortgage payment removed = %v, want %v", paidOff, test.wantPaidOff)
+			}
+		})
+	}
+}
+
+func TestMortgagePaidOffOnSale(t *testing.T) {
+	world := newTestWorld(date(2024, time.January, 15))
+	borrower := addTestPlayer(world, 0)
+	property := addTestProperty(world, borrower, 150000, 1000, date(2024, time.January, 15))
+	loan := addTestMortgage(world, property, borrower, 120000, 0.06, 360, date(2024, time.January, 15))
+
+	gameTime, _ := world.GetCurrentGameTime()
+	gameTime.CurrentDate = date(2024, time.April, 10)
+	(&RecurringPaymentSystem{}).Update(world)
+	outstanding := loan.OutstandingPrincipal
+
+	breakdown := SellProperty(world, property)
+
+	if breakdown.MortgagePayoff != outstanding {
+		t.Errorf("mortgage payoff = %.2f, want %.2f", breakdown.MortgagePayoff, outstanding)
+	}
+	if want := breakdown.AmountRealized - breakdown.CapitalGainsTax - outstanding; breakdown.NetProceeds != want {
+		t.Errorf("net proceeds = %.2f, want %.2f", breakdown.NetProceeds, want)
+	}
+	if principalPaid := -ledgerTotal(borrower, components.LoanPrincipal, property.ID); math.Abs(principalPaid-120000) > 0.01 {
+		t.Errorf("principal paid = %.2f, want 120000", principalPaid)
+	}
+	if _, err := property.GetLoan(); err == nil {
+		t.Errorf("loan still attached to the sold property")
+	}
+	if count := mortgagePaymentCount(world); count != 0 {
+		t.Errorf("%d mortgage payments left after the sale", count)
+	}
+
+	// No further payments are taken once the property is sold
+	gameTime.CurrentDate = date(2024, time.June, 1)
+	(&RecurringPaymentSystem{}).Update(world)
+	if loan.PaymentsMade != 3 {
+		t.Errorf("payments made = %d, want 3", loan.PaymentsMade)
+	}
+}
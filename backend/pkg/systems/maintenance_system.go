@@ -0,0 +1,159 @@
+package systems
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/*
+===========================================================
+
+	Maintenance system
+
+===========================================================
+
+1. **Wear**
+  - Owned properties are simulated once for every full month of ownership.
+  - Condition decays every month; unrepaired damage doubles the decay.
+  - Routine maintenance accrues monthly as a small percentage of the property value.
+
+2. **Damage Events**
+  - Each month, every damage event possible for the property's type and subtype is rolled.
+  - A damage event adds its repair cost to the maintenance due and knocks down the condition.
+
+3. **Rent**
+  - Properties in poor condition collect less rent (see conditionRentMultiplier).
+
+4. **Repairs**
+  - Players pay down maintenance due with the repair_property action, which restores condition
+    in proportion to the share of the maintenance due that was paid.
+
+===========================================================
+*/
+type MaintenanceSystem struct{}
+
+const (
+	monthlyConditionDecay        = 0.5
+	routineMaintenancePercentage = 0.05 // Percentage of the property value accrued each month
+	poorConditionThreshold       = 70.0 // Rent is reduced below this condition
+	minConditionRentMultiplier   = 0.5  // Rent multiplier at condition 0
+)
+
+type damageEvent struct {
+	Name           string
+	MonthlyChance  float64
+	CostPercentage float64 // Repair cost as a percentage of the property value
+	ConditionLoss  float64
+}
+
+var residentialDamageEvents = []damageEvent{
+	{Name: "HVAC_Break", MonthlyChance: 0.02, CostPercentage: 0.8, ConditionLoss: 10},
+	{Name: "Roof_Leak", MonthlyChance: 0.015, CostPercentage: 1.2, ConditionLoss: 12},
+	{Name: "Plumbing_Leak", MonthlyChance: 0.03, CostPercentage: 0.4, ConditionLoss: 6},
+	{Name: "Appliance_Failure", MonthlyChance: 0.04, CostPercentage: 0.2, ConditionLoss: 3},
+}
+
+var commercialDamageEvents = []damageEvent{
+	{Name: "HVAC_Break", MonthlyChance: 0.025, CostPercentage: 0.6, ConditionLoss: 10},
+	{Name: "Roof_Leak", MonthlyChance: 0.015, CostPercentage: 0.8, ConditionLoss: 12},
+	{Name: "Electrical_Fault", MonthlyChance: 0.02, CostPercentage: 0.3, ConditionLoss: 8},
+	{Name: "Storefront_Damage", MonthlyChance: 0.02, CostPercentage: 0.2, ConditionLoss: 4},
+}
+
+var kitchenFailure = damageEvent{Name: "Kitchen_Equipment_Failure", MonthlyChance: 0.04, CostPercentage: 0.4, ConditionLoss: 6}
+var elevatorOutage = damageEvent{Name: "Elevator_Outage", MonthlyChance: 0.02, CostPercentage: 0.5, ConditionLoss: 8}
+var equipmentFailure = damageEvent{Name: "Equipment_Failure", MonthlyChance: 0.04, CostPercentage: 0.3, ConditionLoss: 5}
+var poolDamage = damageEvent{Name: "Pool_Damage", MonthlyChance: 0.02, CostPercentage: 0.3, ConditionLoss: 5}
+
+// Damage events specific to a subtype, rolled in addition to the events for the property type
+var subtypeDamageEvents = map[components.PropertySubtype][]damageEvent{
+	components.Apartment:    {elevatorOutage},
+	components.Condo:        {elevatorOutage},
+	components.Penthouse:    {elevatorOutage},
+	components.Hotel:        {elevatorOutage, poolDamage, kitchenFailure},
+	components.Mall:         {elevatorOutage},
+	components.Bakery:       {kitchenFailure},
+	components.Cafe:         {kitchenFailure},
+	components.Restaurant:   {kitchenFailure},
+	components.IceCreamShop: {kitchenFailure},
+	components.Gym:          {equipmentFailure},
+	components.Arcade:       {equipmentFailure},
+	components.GamingCenter: {equipmentFailure},
+	components.Spa:          {poolDamage},
+}
+
+func (s *MaintenanceSystem) Update(world *ecs.World) {
+	gameTime, _ := world.GetCurrentGameTime()
+	if gameTime.IsPaused {
+		return
+	}
+
+	for _, property := range world.GetAllProperties() {
+		maintainable, err := property.GetMaintainable()
+		if err != nil {
+			continue
+		}
+
+		ownable, _ := property.GetOwnable()
+		if !ownable.Owned || maintainable.LastUpdated.IsZero() {
+			// Wear is only simulated while a property is owned
+			maintainable.LastUpdated = gameTime.CurrentDate
+			continue
+		}
+
+		for !maintainable.LastUpdated.AddDate(0, 1, 0).After(gameTime.CurrentDate) {
+			simulateMonthOfWear(property, maintainable)
+			maintainable.LastUpdated = maintainable.LastUpdated.AddDate(0, 1, 0)
+		}
+	}
+}
+
+func simulateMonthOfWear(property *ecs.Entity, maintainable *components.Maintainable) {
+	value := PropertyValue(property)
+
+	decay := monthlyConditionDecay
+	if len(maintainable.DamageEvents) > 0 {
+		decay *= 2
+	}
+	maintainable.Condition -= decay
+	maintainable.MaintenanceDue += value * routineMaintenancePercentage / 100
+
+	for _, event := range possibleDamageEvents(property) {
+		if rand.Float64() >= event.MonthlyChance {
+			continue
+		}
+		maintainable.DamageEvents = append(maintainable.DamageEvents, event.Name)
+		maintainable.MaintenanceDue += value * event.CostPercentage / 100
+		maintainable.Condition -= event.ConditionLoss
+		fmt.Printf("Damage event %s on property ID %d\n", event.Name, property.ID)
+	}
+
+	if maintainable.Condition < 0 {
+		maintainable.Condition = 0
+	}
+}
+
+func possibleDamageEvents(property *ecs.Entity) []damageEvent {
+	classifiable, err := property.GetClassifiable()
+	if err != nil {
+		return nil
+	}
+
+	events := residentialDamageEvents
+	if classifiable.Type == components.Commercial {
+		events = commercialDamageEvents
+	}
+	return append(append([]damageEvent{}, events...), subtypeDamageEvents[classifiable.Subtype]...)
+}
+
+// conditionRentMultiplier scales rent down linearly once a property falls below the poor condition threshold.
+func conditionRentMultiplier(property *ecs.Entity) float64 {
+	maintainable, err := property.GetMaintainable()
+	if err != nil || maintainable.Condition >= poorConditionThreshold {
+		return 1
+	}
+	return minConditionRentMultiplier + (1-minConditionRentMultiplier)*maintainable.Condition/poorConditionThreshold
+}
@@ -0,0 +1,128 @@
+package systems
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/*
+===========================================================
+
+	Market index system
+
+===========================================================
+
+1. **Monthly Readings**
+  - At the start of every month each neighborhood records the average market value and market rent of its properties.
+  - The price and rent indexes are those averages relative to the first reading, which is 100.
+  - Every reading is kept as a point in the neighborhood's history for charting.
+
+2. **Local Appreciation**
+  - After each reading the neighborhood's local factor moves by its own monthly growth, on top of the economy's price index:
+  - *Trend:* Desirable neighborhoods appreciate faster, 0.01% a month per point of desirability above average,
+    and less desirable ones fall behind.
+  - *Momentum:* When rents have outgrown prices over the past year, prices catch up by a quarter of the gap, and vice versa.
+  - *Shock:* A random monthly change with the neighborhood's volatility.
+
+3. **Effects**
+  - Property values are multiplied by their neighborhood's local factor (see the valuation system),
+    so some neighborhoods outperform others over time.
+
+===========================================================
+*/
+type MarketIndexSystem struct{}
+
+const (
+	marketIndexTrendPerPoint = 0.01 // Monthly local growth in percent per point of desirability above average
+	marketIndexMomentum      = 0.25 // Share of last year's rent-over-price growth gap that prices catch up, spread over a year
+)
+
+func (s *MarketIndexSystem) Update(world *ecs.World) {
+	gameTime, _ := world.GetCurrentGameTime()
+	if gameTime.IsPaused {
+		return
+	}
+
+	for _, neighborhood := range world.QueryByComponent("MarketIndex") {
+		marketIndex, _ := neighborhood.GetMarketIndex()
+		if !marketIndex.LastUpdated.IsZero() && nextMonthStart(marketIndex.LastUpdated).After(gameTime.CurrentDate) {
+			continue
+		}
+		recordMarketIndex(world, neighborhood, marketIndex, gameTime.CurrentDate)
+		advanceLocalFactor(neighborhood, marketIndex)
+		marketIndex.LastUpdated = gameTime.CurrentDate
+	}
+}
+
+// recordMarketIndex takes the month's reading of the neighborhood's average property value and rent.
+func recordMarketIndex(world *ecs.World, neighborhood *ecs.Entity, marketIndex *components.MarketIndex, date time.Time) {
+	groupable, err := neighborhood.GetGroupable()
+	if err != nil {
+		return
+	}
+	properties := propertiesInGroup(world, groupable.GroupID)
+	if len(properties) == 0 {
+		return
+	}
+
+	totalPrice, totalRent := 0.0, 0.0
+	for _, property := range properties {
+		totalPrice += PropertyValue(property)
+		totalRent += DesiredRent(world, property, date)
+	}
+	averagePrice := totalPrice / float64(len(properties))
+	averageRent := totalRent / float64(len(properties))
+
+	if marketIndex.BasePrice <= 0 || marketIndex.BaseRent <= 0 {
+		marketIndex.BasePrice = averagePrice
+		marketIndex.BaseRent = averageRent
+	}
+	marketIndex.PriceIndex = 100 * averagePrice / marketIndex.BasePrice
+	marketIndex.RentIndex = 100 * averageRent / marketIndex.BaseRent
+
+	marketIndex.History = append(marketIndex.History, components.MarketIndexPoint{
+		Date:         date,
+		PriceIndex:   marketIndex.PriceIndex,
+		RentIndex:    marketIndex.RentIndex,
+		AveragePrice: averagePrice,
+		AverageRent:  averageRent,
+		LocalFactor:  marketIndex.LocalFactor,
+	})
+}
+
+// advanceLocalFactor applies a month of the neighborhood's own appreciation.
+func advanceLocalFactor(neighborhood *ecs.Entity, marketIndex *components.MarketIndex) {
+	growth := rand.NormFloat64() * marketIndex.Volatility
+	if desirability, err := neighborhood.GetDesirability(); err == nil {
+		growth += marketIndexTrendPerPoint * (desirability.Value - averageDesirability)
+	}
+
+	if history := marketIndex.History; len(history) > 12 {
+		current, yearAgo := history[len(history)-1], history[len(history)-13]
+		rentGrowth := 100 * (current.RentIndex/yearAgo.RentIndex - 1)
+		priceGrowth := 100 * (current.PriceIndex/yearAgo.PriceIndex - 1)
+		growth += marketIndexMomentum * (rentGrowth - priceGrowth) / 12
+	}
+
+	marketIndex.LocalFactor *= 1 + growth/100
+}
+
+// locationValueFactor is the local appreciation of the property's neighborhood, 1.0 if it has no market index.
+func locationValueFactor(world *ecs.World, property *ecs.Entity) float64 {
+	groupable, err := property.GetGroupable()
+	if err != nil {
+		return 1
+	}
+	neighborhood := world.GetNeighborhood(groupable.GroupID)
+	if neighborhood == nil {
+		return 1
+	}
+	marketIndex, err := neighborhood.GetMarketIndex()
+	if err != nil {
+		return 1
+	}
+	return marketIndex.LocalFactor
+}
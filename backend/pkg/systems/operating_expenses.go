@@ -0,0 +1,85 @@
+package systems
+
+import (
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/*
+===========================================================
+
+	Operating expenses
+
+===========================================================
+
+Operating expenses are charged to the owner as part of the monthly rent cycle:
+  - **Utilities:** Fixed monthly amount per unit raised by inflation, prorated by the days the property was owned in the month.
+  - **Insurance:** Yearly percentage of the property value, charged monthly and prorated the same way.
+  - **Management Fees:** Percentage of the rent collected for the month.
+  - **Vacancy Reserve:** Percentage of the rent collected for the month, set aside for vacancies.
+
+The breakdown for the most recent month is kept on the property's OperatingExpenses component.
+
+===========================================================
+*/
+
+// calculateOperatingExpenses builds the operating expense breakdown for a property over the month,
+// given the rent collected for the same period. It returns nil if the property has no operating expenses.
+func calculateOperatingExpenses(world *ecs.World, property *ecs.Entity, rent float64, monthStart, monthEnd time.Time) *components.OperatingExpenseBreakdown {
+	operatingExpenses, err := property.GetOperatingExpenses()
+	if err != nil {
+		return nil
+	}
+	rates := operatingExpenses.Rates
+
+	activeShare := float64(ownedDaysInMonth(property, monthStart, monthEnd)) / float64(daysInMonth(monthStart))
+
+	breakdown := &components.OperatingExpenseBreakdown{
+		PeriodStart: monthStart,
+		PeriodEnd:   monthEnd,
+		GrossRent:   rent,
+		Expenses: map[components.TransactionCategory]float64{
+			components.Utilities:      monthlyUtilities(property, rates) * InflationIndex(world) * activeShare,
+			components.Insurance:      PropertyValue(property) * rates.AnnualInsuranceRate / 100 / 12 * activeShare,
+			components.ManagementFees: rent * rates.ManagementFeePercentage / 100,
+			components.VacancyReserve: rent * rates.VacancyReservePercentage / 100,
+		},
+	}
+	for _, amount := range breakdown.Expenses {
+		breakdown.TotalExpenses += amount
+	}
+	breakdown.NetRent = breakdown.GrossRent - breakdown.TotalExpenses
+
+	return breakdown
+}
+
+// monthlyUtilities is the property's utilities bill before inflation: the rate for each of its units.
+func monthlyUtilities(property *ecs.Entity, rates components.OperatingExpenseRates) float64 {
+	units := 1
+	if rentable, err := property.GetRentable(); err == nil && len(rentable.Units) > 1 {
+		units = len(rentable.Units)
+	}
+	return rates.MonthlyUtilities * float64(units)
+}
+
+// chargeOperatingExpenses debits each expense in the breakdown from the property owners, booked on the last day
+// of the month they cover, and records the breakdown on the property.
+func chargeOperatingExpenses(world *ecs.World, property *ecs.Entity, breakdown *components.OperatingExpenseBreakdown, monthEnd time.Time) {
+	operatingExpenses, _ := property.GetOperatingExpenses()
+	operatingExpenses.LastMonth = breakdown
+
+	for category, amount := range breakdown.Expenses {
+		if amount > 0 {
+			PostPropertyTransaction(world, property, monthEnd, category, -amount, "Operating expenses")
+		}
+	}
+}
+
+// ownedDaysInMonth counts the days in the range the property was owned, excluding the purchase day.
+func ownedDaysInMonth(property *ecs.Entity, monthStart, monthEnd time.Time) int {
+	purchaseable, _ := property.GetPurchaseable()
+	ownedStart := maxTime(purchaseable.PurchaseDate.AddDate(0, 0, 1), monthStart)
+	return countDaysInRange(ownedStart, monthEnd)
+}
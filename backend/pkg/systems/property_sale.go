@@ -0,0 +1,168 @@
+package systems
+
+import (
+	"math"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/*
+===========================================================
+
+	Property sales
+
+===========================================================
+
+1. **Amount Realized**
+  - Properties sell at their market value less selling costs.
+  - Foreclosed properties sell for the winning auction bid, without selling costs (see auction_system.go).
+
+2. **Cost Basis**
+  - Purchase price plus the cost of upgrades bought during the current ownership,
+    less the depreciation accumulated since each was bought.
+  - Only the building share of the purchase price depreciates; upgrades depreciate in full.
+  - Depreciation is straight-line over 27.5 years for residential and 39 years for commercial properties.
+
+3. **Capital Gains Tax**
+  - The realized gain is the amount realized less the cost basis.
+  - Gains on properties held for at least a year are taxed at the long-term rate, otherwise at the short-term rate.
+  - Losses are not taxed.
+
+4. **Mortgage**
+  - Any outstanding mortgage is paid off from the proceeds.
+  - Co-owned properties split the proceeds and tax between the shareholders; the borrower pays off the mortgage.
+
+5. **Tenant**
+  - The tenant moves out and the seller settles their security deposit.
+
+===========================================================
+*/
+
+// SaleBreakdown details the proceeds and taxes of a property sale.
+type SaleBreakdown struct {
+	PropertyID              int       `json:"property_id"`
+	SaleDate                time.Time `json:"sale_date"`
+	MarketValue             float64   `json:"market_value"`
+	SellingCosts            float64   `json:"selling_costs"`
+	AmountRealized          float64   `json:"amount_realized"`
+	PurchasePrice           float64   `json:"purchase_price"`
+	UpgradeCosts            float64   `json:"upgrade_costs"`
+	AccumulatedDepreciation float64   `json:"accumulated_depreciation"`
+	CostBasis               float64   `json:"cost_basis"`
+	RealizedGain            float64   `json:"realized_gain"`
+	HoldingPeriodDays       int       `json:"holding_period_days"`
+	LongTerm                bool      `json:"long_term"`
+	CapitalGainsRate        float64   `json:"capital_gains_rate"`
+	CapitalGainsTax         float64   `json:"capital_gains_tax"`
+	MortgagePayoff          float64   `json:"mortgage_payoff"`
+	NetProceeds             float64   `json:"net_proceeds"`
+}
+
+// SellProperty sells an owned property at its sale price, pays capital gains tax and any mortgage
+// from the proceeds, settles the tenant's security deposit and releases the property back to the market.
+func SellProperty(world *ecs.World, property *ecs.Entity) *SaleBreakdown {
+	gameTime, _ := world.GetCurrentGameTime()
+	return sellProperty(world, property, CalculateSale(property, gameTime.CurrentDate))
+}
+
+// sellProperty carries out a sale on its owners' behalf as set out in the breakdown.
+func sellProperty(world *ecs.World, property *ecs.Entity, breakdown *SaleBreakdown) *SaleBreakdown {
+	ownable, _ := property.GetOwnable()
+	gameTime, _ := world.GetCurrentGameTime()
+
+	// Proceeds and taxes are split between the shareholders
+	PostPropertyTransaction(world, property, gameTime.CurrentDate, components.SaleProceeds, breakdown.AmountRealized, "Property sale")
+	if breakdown.CapitalGainsTax > 0 {
+		PostPropertyTransaction(world, property, gameTime.CurrentDate, components.CapitalGainsTax, -breakdown.CapitalGainsTax, "Capital gains tax")
+	}
+
+	// The borrower pays off any outstanding mortgage from their proceeds
+	if loan, err := property.GetLoan(); err == nil && breakdown.MortgagePayoff > 0 {
+		if borrower := world.GetEntity(loan.BorrowerID); borrower != nil {
+			borrower.PostTransaction(gameTime.CurrentDate, components.LoanPrincipal, -breakdown.MortgagePayoff, property.ID, "Mortgage payoff")
+		}
+	}
+	world.RemoveLoanFromProperty(property)
+	cancelMortgagePayments(world, property.ID)
+
+	// The tenants move out and their security deposits are settled by the seller
+	if rentable, err := property.GetRentable(); err == nil {
+		for _, unit := range rentable.Units {
+			moveOutTenant(world, property, unit, gameTime.CurrentDate)
+		}
+	}
+
+	// Remove the property from the owner's properties
+	world.SellProperty(property.ID)
+	releaseOwnership(ownable)
+
+	return breakdown
+}
+
+// CalculateSale computes the breakdown of selling a property on the given date without selling it.
+func CalculateSale(property *ecs.Entity, saleDate time.Time) *SaleBreakdown {
+	return calculateSale(property, saleDate, PropertyValue(property), SalePrice(property))
+}
+
+// calculateSale computes the breakdown of selling a property for the given price, net of selling costs.
+func calculateSale(property *ecs.Entity, saleDate time.Time, marketValue, amountRealized float64) *SaleBreakdown {
+	purchaseable, _ := property.GetPurchaseable()
+
+	breakdown := &SaleBreakdown{
+		PropertyID:        property.ID,
+		SaleDate:          saleDate,
+		MarketValue:       marketValue,
+		AmountRealized:    amountRealized,
+		PurchasePrice:     purchaseable.Cost,
+		HoldingPeriodDays: int(saleDate.Sub(purchaseable.PurchaseDate).Hours() / 24),
+		LongTerm:          !saleDate.Before(purchaseable.PurchaseDate.AddDate(1, 0, 0)),
+	}
+	breakdown.SellingCosts = breakdown.MarketValue - breakdown.AmountRealized
+
+	depreciationYears := propertyDepreciationYears(property)
+	breakdown.AccumulatedDepreciation = depreciation(purchaseable.Cost*components.BuildingValuePercentage/100, purchaseable.PurchaseDate, saleDate, depreciationYears)
+	if upgradable, err := property.GetUpgradable(); err == nil {
+		for _, upgrade := range upgradable.AppliedUpgrades {
+			if upgrade.PurchaseDate.Before(purchaseable.PurchaseDate) {
+				// Bought by a previous owner
+				continue
+			}
+			breakdown.UpgradeCosts += upgrade.Cost
+			breakdown.AccumulatedDepreciation += depreciation(upgrade.Cost, upgrade.PurchaseDate, saleDate, depreciationYears)
+		}
+	}
+	breakdown.CostBasis = breakdown.PurchasePrice + breakdown.UpgradeCosts - breakdown.AccumulatedDepreciation
+	breakdown.RealizedGain = breakdown.AmountRealized - breakdown.CostBasis
+
+	breakdown.CapitalGainsRate = components.ShortTermCapitalGainsRate
+	if breakdown.LongTerm {
+		breakdown.CapitalGainsRate = components.LongTermCapitalGainsRate
+	}
+	breakdown.CapitalGainsTax = math.Max(breakdown.RealizedGain, 0) * breakdown.CapitalGainsRate
+
+	if loan, err := property.GetLoan(); err == nil {
+		breakdown.MortgagePayoff = loan.OutstandingPrincipal
+	}
+	breakdown.NetProceeds = breakdown.AmountRealized - breakdown.CapitalGainsTax - breakdown.MortgagePayoff
+
+	return breakdown
+}
+
+func propertyDepreciationYears(property *ecs.Entity) float64 {
+	classifiable, err := property.GetClassifiable()
+	if err != nil {
+		return components.DepreciationYears[components.Residential]
+	}
+	return components.DepreciationYears[classifiable.Type]
+}
+
+// depreciation returns the straight-line depreciation of an amount between two dates, capped at the amount.
+func depreciation(amount float64, from, to time.Time, years float64) float64 {
+	if years <= 0 || !to.After(from) {
+		return 0
+	}
+	yearsHeld := to.Sub(from).Hours() / 24 / 365.25
+	return math.Min(amount, amount*yearsHeld/years)
+}
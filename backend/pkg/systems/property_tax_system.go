@@ -0,0 +1,137 @@
+package systems
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/*
+===========================================================
+
+	Property tax system
+
+===========================================================
+
+1. **Assessment**
+  - Owned properties are assessed once per year, on the first update of the new year.
+  - The assessed value is the property's current value.
+  - The bill is the assessed value times the neighborhood's millage rate (tax per $1,000).
+  - The owner on assessment day is responsible for the whole year, even if the property is sold later.
+  - A property bought during a year it wasn't assessed for is assessed on its first update after the purchase,
+    with the bill prorated from the purchase date to the end of the year. If the neighborhood's due date has already
+    passed, the bill is due 30 days after assessment instead.
+
+2. **Payment**
+  - Bills are paid automatically from the owner's funds on the neighborhood's due date.
+  - If the owner cannot cover the balance, the bill becomes late and is retried every update.
+
+3. **Late Penalties**
+  - A late bill is charged the neighborhood's late penalty rate on its unpaid balance
+    the day after the due date and again every month until it is paid.
+
+===========================================================
+*/
+type PropertyTaxSystem struct{}
+
+func (s *PropertyTaxSystem) Update(world *ecs.World) {
+	gameTime, _ := world.GetCurrentGameTime()
+	if gameTime.IsPaused {
+		return
+	}
+	currentDate := gameTime.CurrentDate
+
+	for _, property := range world.GetAllProperties() {
+		taxable, err := property.GetTaxable()
+		if err != nil {
+			continue
+		}
+		groupable, _ := property.GetGroupable()
+		neighborhood := world.GetNeighborhood(groupable.GroupID)
+		if neighborhood == nil {
+			continue
+		}
+		district, err := neighborhood.GetTaxDistrict()
+		if err != nil {
+			continue
+		}
+
+		ownable, _ := property.GetOwnable()
+		if ownable.Owned && taxable.LastAssessmentYear < currentDate.Year() {
+			assessProperty(property, taxable, district, ownable.OwnerID, currentDate)
+		}
+
+		for _, bill := range taxable.Bills {
+			if bill.Paid || currentDate.Before(bill.DueDate) {
+				continue
+			}
+			payTaxBill(world, property, bill, currentDate)
+			applyLatePenalties(bill, district, currentDate)
+		}
+	}
+}
+
+func assessProperty(property *ecs.Entity, taxable *components.Taxable, district *components.TaxDistrict, ownerID int, currentDate time.Time) {
+	year := currentDate.Year()
+	assessedValue := PropertyValue(property)
+	amount := assessedValue * district.MillageRate / 1000
+	dueDate := time.Date(year, district.DueMonth, district.DueDay, 0, 0, 0, 0, time.UTC)
+
+	// Properties bought during the year only pay for the rest of it, and never a bill that's already late
+	yearStart := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	yearEnd := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+	if purchaseable, err := property.GetPurchaseable(); err == nil && purchaseable.PurchaseDate.After(yearStart) {
+		amount *= float64(countDaysInRange(purchaseable.PurchaseDate, yearEnd)) / float64(countDaysInRange(yearStart, yearEnd))
+	}
+	if currentDate.After(dueDate) {
+		dueDate = currentDate.AddDate(0, 0, components.LateAssessmentDueDays)
+	}
+
+	taxable.AssessedValue = assessedValue
+	taxable.LastAssessmentYear = year
+	taxable.Bills = append(taxable.Bills, &components.TaxBill{
+		OwnerID:         ownerID,
+		Year:            year,
+		AssessedValue:   assessedValue,
+		MillageRate:     district.MillageRate,
+		Amount:          amount,
+		DueDate:         dueDate,
+		NextPenaltyDate: dueDate.AddDate(0, 0, 1),
+	})
+	fmt.Printf("Property ID %d assessed at %.2f for %d\n", property.ID, assessedValue, year)
+}
+
+func payTaxBill(world *ecs.World, property *ecs.Entity, bill *components.TaxBill, currentDate time.Time) {
+	owner := world.GetEntity(bill.OwnerID)
+	if owner == nil {
+		return
+	}
+	balance := bill.Balance()
+	description := fmt.Sprintf("%d property tax", bill.Year)
+
+	// Bills of the current owner are split between the shareholders; bills left by a previous owner are theirs alone
+	if ownable, _ := property.GetOwnable(); ownable.Owned && ownable.OwnerID == bill.OwnerID {
+		if canAfford, _ := CanShareholdersAfford(world, property, balance); !canAfford {
+			return
+		}
+		PostPropertyTransaction(world, property, currentDate, components.PropertyTax, -balance, description)
+	} else {
+		funds, _ := owner.GetFunds()
+		if funds.Amount < balance {
+			return
+		}
+		owner.PostTransaction(currentDate, components.PropertyTax, -balance, property.ID, description)
+	}
+	bill.Paid = true
+	bill.PaidDate = currentDate
+	fmt.Printf("Property tax of %.2f paid by player ID %d for property ID %d\n", balance, owner.ID, property.ID)
+}
+
+func applyLatePenalties(bill *components.TaxBill, district *components.TaxDistrict, currentDate time.Time) {
+	for !bill.Paid && !bill.NextPenaltyDate.After(currentDate) {
+		bill.Penalty += bill.Balance() * district.LatePenaltyRate
+		bill.NextPenaltyDate = bill.NextPenaltyDate.AddDate(0, 1, 0)
+	}
+}
@@ -0,0 +1,82 @@
+package systems
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+)
+
+func TestAssessProperty(t *testing.T) {
+	district := &components.TaxDistrict{MillageRate: 10, DueMonth: time.April, DueDay: 15, LatePenaltyRate: 0.015}
+
+	tests := []struct {
+		name         string
+		purchaseDate time.Time
+		assessedOn   time.Time
+		wantAmount   float64
+		wantDueDate  time.Time
+	}{
+		{
+			name:         "owned all year",
+			purchaseDate: date(2023, time.June, 1),
+			assessedOn:   date(2024, time.January, 1),
+			wantAmount:   2000,
+			wantDueDate:  date(2024, time.April, 15),
+		},
+		{
+			name:         "bought on the first day of the year",
+			purchaseDate: date(2024, time.January, 1),
+			assessedOn:   date(2024, time.January, 1),
+			wantAmount:   2000,
+			wantDueDate:  date(2024, time.April, 15),
+		},
+		{
+			name:         "bought before the due date",
+			purchaseDate: date(2024, time.February, 1),
+			assessedOn:   date(2024, time.February, 1),
+			wantAmount:   2000 * 335.0 / 366, // February 1 to December 31 of a leap year
+			wantDueDate:  date(2024, time.April, 15),
+		},
+		{
+			name:         "bought after the due date",
+			purchaseDate: date(2024, time.July, 1),
+			assessedOn:   date(2024, time.July, 1),
+			wantAmount:   2000 * 184.0 / 366,
+			wantDueDate:  date(2024, time.July, 31),
+		},
+		{
+			name:         "bought on the last day of the year",
+			purchaseDate: date(2024, time.December, 31),
+			assessedOn:   date(2024, time.December, 31),
+			wantAmount:   2000 * 1.0 / 366,
+			wantDueDate:  date(2025, time.January, 30),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			world := newTestWorld(test.assessedOn)
+			owner := addTestPlayer(world, 0)
+			property := addTestProperty(world, owner, 200000, 1000, test.purchaseDate)
+			taxable, _ := property.GetTaxable()
+
+			assessProperty(property, taxable, district, owner.ID, test.assessedOn)
+
+			if len(taxable.Bills) != 1 {
+				t.Fatalf("%d bills, want 1", len(taxable.Bills))
+			}
+			bill := taxable.Bills[0]
+			if math.Abs(bill.Amount-test.wantAmount) > 0.01 {
+				t.Errorf("bill = %.2f, want %.2f", bill.Amount, test.wantAmount)
+			}
+			if !bill.DueDate.Equal(test.wantDueDate) {
+				t.Errorf("due %v, want %v", bill.DueDate, test.wantDueDate)
+			}
+			if bill.OwnerID != owner.ID || taxable.LastAssessmentYear != test.assessedOn.Year() {
+				t.Errorf("bill owner %d for %d, want %d for %d", bill.OwnerID, taxable.LastAssessmentYear, owner.ID, test.assessedOn.Year())
+			}
+		})
+	}
+}
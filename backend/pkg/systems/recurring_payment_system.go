@@ -0,0 +1,147 @@
+package systems
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/*
+===========================================================
+
+	Recurring payment system
+
+===========================================================
+
+1. **Obligations**
+  - A recurring payment moves a fixed amount from a payer to a payee every month, quarter or year,
+    from its start date until its end date, if it has one.
+  - Payers and payees are players, properties or parties outside the game such as tenants and utility companies.
+  - Amounts paid to or by a property are split between its shareholders (see co_ownership.go).
+  - Due dates falling on a day a month doesn't have move to the last day of that month.
+  - Mortgage payments are recurring payments from the borrower (see loan_system.go).
+  - Rent is not a recurring payment; it varies month to month and is collected by the rent collection system.
+
+2. **Payment**
+  - Every payment that has fallen due is made in order, so fast-forwarded time is caught up.
+  - Both sides are posted to the ledger under the payment's category.
+
+3. **Failure Policy**
+  - A payment fails if the payer cannot afford it or is bankrupt. Outside parties always pay.
+  - *Skip:* The payment is missed and never made up.
+  - *Accrue:* The amount is carried over and due with the next payment.
+  - *Cancel:* The obligation ends.
+  - *Debit:* The payment is made anyway and may push the payer's funds below zero (see bankruptcy_system.go).
+    Only mortgage payments use it; players can't schedule payments with it.
+  - Payments from a bankrupt player always fail, whatever the policy.
+  - Finished and cancelled obligations are removed.
+
+===========================================================
+*/
+type RecurringPaymentSystem struct{}
+
+func (s *RecurringPaymentSystem) Update(world *ecs.World) {
+	gameTime, _ := world.GetCurrentGameTime()
+	if gameTime.IsPaused {
+		return
+	}
+
+	for _, entity := range world.QueryByComponent("RecurringPayment") {
+		payment, err := entity.GetRecurringPayment()
+		if err != nil {
+			continue
+		}
+
+		for !payment.IsFinished() && !payment.NextDueDate.After(gameTime.CurrentDate) {
+			makeRecurringPayment(world, payment, payment.NextDueDate)
+			payment.DueDatesPassed++
+			payment.NextDueDate = payment.DueDate(payment.DueDatesPassed)
+		}
+
+		if payment.IsFinished() {
+			world.RemoveEntity(entity.ID)
+		}
+	}
+}
+
+// TransferFunds moves an amount from the payer to the payee and posts it to both ledgers.
+// Either side may be a player, a property, whose shareholders split the amount, or an outside party.
+func TransferFunds(world *ecs.World, payerID, payeeID int, date time.Time, category components.TransactionCategory, amount float64, propertyID int, description string) {
+	postTransferSide(world, payerID, date, category, -amount, propertyID, description)
+	postTransferSide(world, payeeID, date, category, amount, propertyID, description)
+}
+
+func postTransferSide(world *ecs.World, partyID int, date time.Time, category components.TransactionCategory, amount float64, propertyID int, description string) {
+	if partyID == components.ExternalParty {
+		return
+	}
+	party := world.GetEntity(partyID)
+	if party == nil {
+		return
+	}
+	if party.Type == "Property" {
+		PostPropertyTransaction(world, party, date, category, amount, description)
+		return
+	}
+	party.PostTransaction(date, category, amount, propertyID, description)
+}
+
+// canPartyAfford reports whether a payer can make a payment of the given amount.
+func canPartyAfford(world *ecs.World, partyID int, amount float64) bool {
+	if partyID == components.ExternalParty {
+		return true
+	}
+	party := world.GetEntity(partyID)
+	if party == nil {
+		return false
+	}
+	if party.Type == "Property" {
+		canAfford, _ := CanShareholdersAfford(world, party, amount)
+		return canAfford
+	}
+	if isBankrupt(party) {
+		return false
+	}
+	funds, err := party.GetFunds()
+	return err == nil && funds.Amount >= amount
+}
+
+// isPartyBankrupt reports whether the party is a bankrupt player.
+func isPartyBankrupt(world *ecs.World, partyID int) bool {
+	if partyID == components.ExternalParty {
+		return false
+	}
+	party := world.GetEntity(partyID)
+	return party != nil && party.Type == "Player" && isBankrupt(party)
+}
+
+// makeRecurringPayment makes the payment due on the given date, applying the failure policy if the payer cannot pay.
+// Bankrupt payers always fail, whatever the policy.
+func makeRecurringPayment(world *ecs.World, payment *components.RecurringPayment, date time.Time) {
+	due := payment.Amount + payment.AmountOverdue
+	if isPartyBankrupt(world, payment.PayerID) ||
+		(payment.FailurePolicy != components.DebitOnFailure && !canPartyAfford(world, payment.PayerID, due)) {
+		payment.ConsecutiveFailures++
+		switch payment.FailurePolicy {
+		case components.AccrueFailedPayment:
+			payment.AmountOverdue = due
+		case components.CancelOnFailure:
+			payment.Cancelled = true
+		}
+		fmt.Printf("Recurring payment %q of %.2f from ID %d failed (%s)\n", payment.Description, due, payment.PayerID, payment.FailurePolicy)
+		return
+	}
+
+	if payment.Mortgage {
+		payMortgage(world, payment, date)
+		return
+	}
+
+	TransferFunds(world, payment.PayerID, payment.PayeeID, date, payment.Category, due, payment.PropertyID, payment.Description)
+	payment.AmountOverdue = 0
+	payment.ConsecutiveFailures = 0
+	payment.PaymentsMade++
+	payment.TotalPaid += due
+}
@@ -1,10 +1,10 @@
 package systems
 
 import (
-	"fmt"
 	"math"
 	"time"
 
+	"github.com/markbmullins/city-developer/pkg/components"
 	"github.com/markbmullins/city-developer/pkg/ecs"
 )
 
@@ -33,11 +33,23 @@ import (
   - **Prorated Rent Calculation:** Based on days owned/upgraded, excluding purchase and upgrade completion days.
   - **Full Rent Collection:** After a full month has elapsed (excluding purchase day), full rent including upgrades is collected.
   - **Rent Composition:**
-  - *Base Rent:* Defined per property.
+  - *Base Rent:* Defined per unit; a property's base rent is the sum over its units.
   - *Upgrade Increases:* Added based on each upgrade's RentIncrease value.
   - *Total Rent:* Sum of Base Rent and all applicable Upgrade Increases.
+  - *Operating Expenses:* Charged to the owner alongside the rent (see operating_expenses.go).
+  - *Units:* Each unit commands its share of the total, in proportion to its base rent.
+  - *Asking Rent:* When the owner sets a rent for a unit, it replaces the unit's share; higher rents take longer to let (see rent_demand.go).
+  - *Occupancy:* Rent is only collected for the days each unit has a tenant, from their move-in day (see tenant_system.go).
+  - *Leases:* Tenants pay the rent locked in their lease, prorated the same way. The standard rent, upgrade increases
+    and asking rent only reach a tenant when they sign or renew a lease (see leases.go).
+  - *Percentage Rent:* Commercial tenants also pay any percentage rent on the month's sales (see business_system.go).
+  - *Tenant Payments:* Rent is charged to each unit's tenant, who may pay late or only in part (see tenant_payments.go).
+  - *Booking:* Rent, late fees and operating expenses are booked on the last day of the month they cover,
+    so they land in that month's financial statements (see financial_statement_system.go).
 
 4. **Time Advancement Considerations**
+  - **Arrears:** Each month's rent is collected on the first update of the following month,
+    before tenants move out (see tenant_system.go).
   - **Variable Speeds:** Supports multiple time advancement speeds, including cycles exceeding 30 days.
   - **Accurate Proration:** Rent calculations adjust based on the actual number of days elapsed, regardless of time speed.
 
@@ -69,18 +81,13 @@ func (s *RentCollectionSystem) Update(world *ecs.World) {
 	}
 }
 
+// processRent collects rent in arrears for every month that has ended since the last update,
+// starting with the month of the last update. Partial months are prorated within processMonth.
 func processRent(world *ecs.World, lastUpdated time.Time, monthsPassed int) {
-	firstOfNextMonth := nextMonthStart(lastUpdated)
+	firstMonth := time.Date(lastUpdated.Year(), lastUpdated.Month(), 1, 0, 0, 0, 0, lastUpdated.Location())
 
-	// Handle partial month (if lastUpdated isn't at the end of its month)
-	if isPartialMonth(lastUpdated) {
-		processMonth(world, lastUpdated, monthEnd(lastUpdated))
-		monthsPassed--
-	}
-
-	// Handle full months
 	for i := 0; i < monthsPassed; i++ {
-		startOfMonth := firstOfNextMonth.AddDate(0, i, 0)
+		startOfMonth := firstMonth.AddDate(0, i, 0)
 		endOfMonth := monthEnd(startOfMonth)
 		processMonth(world, startOfMonth, endOfMonth)
 	}
@@ -92,9 +99,25 @@ func processMonth(world *ecs.World, startDate, endDate time.Time) {
 		for _, ownedPropertyEntity := range ownedProperties {
 			ownable, _ := ownedPropertyEntity.GetOwnable()
 			if ownable.Owned && ownable.OwnerID == ownerID {
-				rent := calculateMonthlyRent(ownedPropertyEntity, startDate, endDate, world)
-				if rent > 0 {
-					distributeRentToOwner(world, ownedPropertyEntity, rent)
+				// Each occupied unit pays its own rent; vacant units collect none but the property still pays its operating expenses
+				rent := 0.0
+				if rentable, err := ownedPropertyEntity.GetRentable(); err == nil {
+					for _, unit := range rentable.Units {
+						// Closed businesses have left by the start of the month after they closed
+						if unit.Tenant == nil || (unit.Tenant.Business != nil && unit.Tenant.Business.Closed && !startDate.Before(unit.Tenant.Business.LastUpdated)) {
+							continue
+						}
+						unitRent := leaseRent(ownedPropertyEntity, unit.Tenant, startDate, endDate)
+						if business := unit.Tenant.Business; business != nil {
+							unitRent += business.PercentageRentDue
+							business.PercentageRentDue = 0
+						}
+						rent += unitRent
+						collectRentFromTenant(world, ownedPropertyEntity, unit, unitRent, endDate)
+					}
+				}
+				if expenses := calculateOperatingExpenses(world, ownedPropertyEntity, rent, startDate, endDate); expenses != nil {
+					chargeOperatingExpenses(world, ownedPropertyEntity, expenses, endDate)
 				}
 			}
 		}
@@ -106,6 +129,7 @@ func processMonth(world *ecs.World, startDate, endDate time.Time) {
 // - No rent on the purchase day; rent begins the day after purchase if within the month.
 // - Each upgrade also begins contributing rent the day after it completes, if within the month.
 // - Both base rent and upgrades are prorated based on the number of days active in the month.
+// - The total is reduced for properties in poor condition, scaled by the economy's rent multiplier and vacancy rate and by the inflation index.
 // - After determining total active days for the property and any upgrades, it rounds the total rent down to the nearest multiple of 5.
 func calculateMonthlyRent(property *ecs.Entity, monthStart, monthEnd time.Time, world *ecs.World) float64 {
 	daysInCurrentMonth := float64(daysInMonth(monthStart))
@@ -169,12 +193,54 @@ func calculateMonthlyRent(property *ecs.Entity, monthStart, monthEnd time.Time,
 	}
 
 	// Total rent is the sum of the prorated base rent and the prorated upgrades rent.
-	totalRent := totalBaseRent + totalUpgradeRent
+	// Properties in poor condition collect less rent, the economy scales rents and inflation raises them over the years.
+	totalRent := (totalBaseRent + totalUpgradeRent) * conditionRentMultiplier(property) * economicRentMultiplier(world) * InflationIndex(world)
 
 	// Round down to the nearest multiple of 5 per the given rounding rule.
 	return roundToNearest5(totalRent)
 }
 
+// StandardRent returns the full monthly rent a property commands on the given date without owner-set rents:
+// the base rent with any neighborhood rent boost plus completed upgrades, adjusted for condition, the economy and inflation.
+func StandardRent(world *ecs.World, property *ecs.Entity, date time.Time) float64 {
+	return roundToNearest5(standardMonthlyRent(world, property, date))
+}
+
+// UnitStandardRent returns the unit's share of the property's standard rent.
+func UnitStandardRent(world *ecs.World, property *ecs.Entity, unit *components.Unit, date time.Time) float64 {
+	rentable, err := property.GetRentable()
+	if err != nil {
+		return 0
+	}
+	return roundToNearest5(standardMonthlyRent(world, property, date) * rentable.UnitShare(unit))
+}
+
+func standardMonthlyRent(world *ecs.World, property *ecs.Entity, date time.Time) float64 {
+	rentable, err := property.GetRentable()
+	if err != nil {
+		return 0
+	}
+	monthlyRent := rentable.BaseRent
+	if rentBoostable, err := property.GetRentBoostable(); err == nil && doesRentBoostApply(property, world) {
+		monthlyRent += (rentBoostable.BoostPercentage / 100) * monthlyRent
+	}
+	monthlyRent += completedUpgradeRent(property, date)
+	return monthlyRent * conditionRentMultiplier(property) * economicRentMultiplier(world) * InflationIndex(world)
+}
+
+// calculateUnitMonthlyRent calculates the rent a unit commands within the given month: the prorated asking rent
+// if the owner has set one, otherwise the unit's share of the property's rent from calculateMonthlyRent.
+func calculateUnitMonthlyRent(property *ecs.Entity, unit *components.Unit, monthStart, monthEnd time.Time, world *ecs.World) float64 {
+	rentable, _ := property.GetRentable()
+	if unit.AskingRent <= 0 {
+		return roundToNearest5(calculateMonthlyRent(property, monthStart, monthEnd, world) * rentable.UnitShare(unit))
+	}
+
+	purchaseable, _ := property.GetPurchaseable()
+	rentStart := maxTime(purchaseable.PurchaseDate.AddDate(0, 0, 1), monthStart)
+	return roundToNearest5(unit.AskingRent / float64(daysInMonth(monthStart)) * float64(countDaysInRange(rentStart, monthEnd)))
+}
+
 func doesRentBoostApply(property *ecs.Entity, world *ecs.World) bool {
 	groupable, _ := property.GetGroupable()
 	rentBoostable, _ := property.GetRentBoostable()
@@ -190,15 +256,6 @@ func doesRentBoostApply(property *ecs.Entity, world *ecs.World) bool {
 	return upgradedPercentage > rentBoostable.ThresholdPercentage
 }
 
-func distributeRentToOwner(world *ecs.World, property *ecs.Entity, rent float64) {
-	// Get all player entities from the world
-	ownable, _ := property.GetOwnable()
-	playerEntity := world.GetEntity(ownable.OwnerID)
-	fundsComponent, _ := playerEntity.GetFunds()
-	fundsComponent.Amount += rent
-	fmt.Printf("Rent of %.2f distributed to player ID %d\n", rent, playerEntity.ID)
-}
-
 func calculateMonthsPassed(lastUpdated, currentDate time.Time) int {
 	if currentDate.Before(lastUpdated) {
 		return 0
@@ -225,10 +282,6 @@ func monthEnd(date time.Time) time.Time {
 	return date.AddDate(0, 1, -date.Day())
 }
 
-func isPartialMonth(date time.Time) bool {
-	return date.Day() != daysInMonth(date)
-}
-
 func roundToNearest5(value float64) float64 {
 	return math.Floor(value/5) * 5
 }
@@ -0,0 +1,96 @@
+package systems
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/entities"
+)
+
+func TestRentCollectedInArrears(t *testing.T) {
+	type rentPayment struct {
+		date   time.Time
+		amount float64
+	}
+
+	tests := []struct {
+		name        string
+		moveIn      time.Time
+		lastUpdated time.Time
+		now         time.Time
+		want        []rentPayment
+	}{
+		{
+			name:        "nothing during the month",
+			moveIn:      date(2024, time.January, 1),
+			lastUpdated: date(2024, time.March, 14),
+			now:         date(2024, time.March, 15),
+			want:        nil,
+		},
+		{
+			name:        "month collected once it has ended",
+			moveIn:      date(2024, time.January, 1),
+			lastUpdated: date(2024, time.March, 31),
+			now:         date(2024, time.April, 1),
+			want:        []rentPayment{{date(2024, time.March, 31), 3100}},
+		},
+		{
+			name:        "every month that ended is caught up",
+			moveIn:      date(2024, time.January, 1),
+			lastUpdated: date(2024, time.January, 20),
+			now:         date(2024, time.April, 5),
+			want: []rentPayment{
+				{date(2024, time.January, 31), 3100},
+				{date(2024, time.February, 29), 3100},
+				{date(2024, time.March, 31), 3100},
+			},
+		},
+		{
+			name:        "first month prorated from the move-in day",
+			moveIn:      date(2024, time.March, 11),
+			lastUpdated: date(2024, time.March, 11),
+			now:         date(2024, time.April, 1),
+			want:        []rentPayment{{date(2024, time.March, 31), 2100}},
+		},
+		{
+			name:        "nothing before the move-in month",
+			moveIn:      date(2024, time.March, 11),
+			lastUpdated: date(2024, time.February, 10),
+			now:         date(2024, time.April, 1),
+			want:        []rentPayment{{date(2024, time.March, 31), 2100}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			world := newTestWorld(test.now)
+			owner := addTestPlayer(world, 0)
+			property := addTestProperty(world, owner, 300000, 3100, date(2023, time.December, 1))
+			rentable, _ := property.GetRentable()
+			rentable.Units[0].Tenant = entities.CreateTenant(components.Residential, 3100, 3100, 12, 0, test.moveIn)
+			gameTime, _ := world.GetCurrentGameTime()
+			gameTime.LastUpdated = test.lastUpdated
+
+			(&RentCollectionSystem{}).Update(world)
+
+			var got []rentPayment
+			ledger, _ := owner.GetLedger()
+			for _, transaction := range ledger.Transactions {
+				if transaction.Category == components.RentIncome {
+					got = append(got, rentPayment{transaction.Date, transaction.Amount})
+				}
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("rent collected = %v, want %v", got, test.want)
+			}
+			if wantNewMonth := test.now.Month() != test.lastUpdated.Month(); gameTime.NewMonth != wantNewMonth {
+				t.Errorf("new month = %v, want %v", gameTime.NewMonth, wantNewMonth)
+			}
+			if !gameTime.LastUpdated.Equal(test.now) {
+				t.Errorf("last updated %v, want %v", gameTime.LastUpdated, test.now)
+			}
+		})
+	}
+}
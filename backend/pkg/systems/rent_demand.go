@@ -0,0 +1,101 @@
+package systems
+
+import (
+	"math"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/*
+===========================================================
+
+	Rent demand
+
+===========================================================
+
+Owners can set an asking rent for each unit of a property. Whether tenants will pay it depends on the rent they desire:
+  - **Desired Rent:** The tenant's desired rent (or the unit's base rent while it is vacant) plus the unit's share of
+    completed upgrades, scaled by the neighborhood's desirability, the property's condition, the economy's rent multiplier
+    and inflation. A property's desired rent is the sum over its units.
+  - **Occupancy Probability:** Falls along an S-curve as the asking rent rises above the desired rent;
+    about 95% at the desired rent, 50% at 125% of it and close to zero beyond 150%.
+    The economy's vacancy rate is applied on top.
+
+The occupancy probability at the asking rent, or at the market rent if none is set, drives how quickly
+vacant units find tenants (see tenant_system.go).
+
+===========================================================
+*/
+
+const (
+	demandElasticity      = 12.0 // Steepness of the occupancy curve
+	demandHalfOccupancy   = 1.25 // Ratio of asking to desired rent at which occupancy is 50%
+	averageDesirability   = 50.0
+	desirabilityRentRange = 0.4 // Desired rent ranges from 80% to 120% across the desirability scale
+)
+
+// DesiredRent returns the monthly rent tenants are willing to pay for the property on the given date,
+// the sum over its units.
+func DesiredRent(world *ecs.World, property *ecs.Entity, date time.Time) float64 {
+	rentable, err := property.GetRentable()
+	if err != nil {
+		return 0
+	}
+	total := 0.0
+	for _, unit := range rentable.Units {
+		total += UnitDesiredRent(world, property, unit, date)
+	}
+	return total
+}
+
+// UnitDesiredRent returns the monthly rent tenants are willing to pay for a unit of the property on the given date.
+// Completed upgrades add to each unit's desired rent in proportion to its share of the property's base rent.
+func UnitDesiredRent(world *ecs.World, property *ecs.Entity, unit *components.Unit, date time.Time) float64 {
+	rentable, err := property.GetRentable()
+	if err != nil {
+		return 0
+	}
+
+	desiredRent := unit.BaseRent
+	if unit.Tenant != nil && unit.Tenant.DesiredRent > 0 {
+		desiredRent = unit.Tenant.DesiredRent
+	}
+	desiredRent += completedUpgradeRent(property, date) * rentable.UnitShare(unit)
+
+	desiredRent *= neighborhoodDesirabilityFactor(world, property)
+	desiredRent *= conditionRentMultiplier(property)
+	if economy, err := world.GetEconomy(); err == nil {
+		desiredRent *= economy.RentMultiplier
+	}
+	return desiredRent * InflationIndex(world)
+}
+
+// OccupancyProbability returns the chance that the unit is occupied at the given asking rent.
+func OccupancyProbability(world *ecs.World, property *ecs.Entity, unit *components.Unit, askingRent float64, date time.Time) float64 {
+	desiredRent := UnitDesiredRent(world, property, unit, date)
+	if desiredRent <= 0 {
+		return 0
+	}
+
+	ratio := askingRent / desiredRent
+	probability := 1 / (1 + math.Exp(demandElasticity*(ratio-demandHalfOccupancy)))
+	if economy, err := world.GetEconomy(); err == nil {
+		probability *= 1 - economy.VacancyRate/100
+	}
+	return probability
+}
+
+func neighborhoodDesirabilityFactor(world *ecs.World, property *ecs.Entity) float64 {
+	groupable, _ := property.GetGroupable()
+	neighborhood := world.GetNeighborhood(groupable.GroupID)
+	if neighborhood == nil {
+		return 1
+	}
+	desirability, err := neighborhood.GetDesirability()
+	if err != nil {
+		return 1
+	}
+	return 1 + desirabilityRentRange*(desirability.Value-averageDesirability)/100
+}
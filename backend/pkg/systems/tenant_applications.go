@@ -0,0 +1,116 @@
+package systems
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+	"github.com/markbmullins/city-developer/pkg/entities"
+)
+
+/*
+===========================================================
+
+	Tenant applications
+
+===========================================================
+
+1. **Applications**
+  - Every day a listed unit receives an application with a chance of its occupancy probability at the advertised rent
+    spread over three weeks, and holds up to five open applications at once.
+  - Each applicant has a monthly income, a credit risk, the rent they think the unit is worth and the lease length they want.
+    They apply at the rent the unit is advertised at that day.
+
+2. **Screening**
+  - The owners can pay $50 to screen an applicant, which reveals their credit score (300 to 850).
+  - Unscreened applicants only show their income, desired rent and lease length.
+
+3. **Decisions**
+  - Accepting an application moves the applicant into the unit that day on a lease of the length they asked for,
+    at the rent they applied at (see tenant_payments.go). The unit's other applicants are turned away.
+  - Rejected applications are removed, making room for new applicants.
+  - Applications the owners haven't answered within a week are accepted by the property manager without screening, oldest first.
+
+4. **Payment Risk**
+  - Tenants with poor credit miss or part-pay rent more often, up to twice as often as average,
+    as do tenants whose rent is more than 40% of their income.
+  - Tenants accepted without screening miss or part-pay rent 50% more often again.
+  - Applicants who value the unit above its rent start out happier (see happiness_system.go).
+
+===========================================================
+*/
+
+// receiveApplications rolls for an application to the unit on each listed day since the last roll and accepts
+// applications the owners have left unanswered for too long.
+func receiveApplications(world *ecs.World, property *ecs.Entity, rentable *components.Rentable, unit *components.Unit, now time.Time) {
+	if now.Before(unit.ListedDate) {
+		return
+	}
+
+	chance := OccupancyProbability(world, property, unit, MarketAskingRent(world, property, unit, now), now) / averageDaysToLet
+	for day := maxTime(unit.ListedDate, unit.LastShowingDate.AddDate(0, 0, 1)); !day.After(now); day = day.AddDate(0, 0, 1) {
+		unit.LastShowingDate = day
+		if len(unit.Applications) < components.MaxOpenApplications && rand.Float64() < chance {
+			application := generateApplication(world, property, rentable, unit, day)
+			unit.Applications = append(unit.Applications, application)
+			fmt.Printf("Property ID %d unit %d received application %d at %.2f\n", property.ID, unit.Number, application.ID, application.Rent)
+		}
+
+		if len(unit.Applications) == 0 {
+			continue
+		}
+		if oldest := unit.Applications[0]; !day.Before(oldest.AppliedDate.AddDate(0, 0, components.ApplicationResponseDays)) {
+			fmt.Printf("Property manager accepted unanswered application %d for property ID %d unit %d\n", oldest.ID, property.ID, unit.Number)
+			AcceptApplication(world, property, unit, oldest, day)
+			return
+		}
+	}
+}
+
+// generateApplication creates an application to rent the unit from a prospective tenant with a random profile.
+func generateApplication(world *ecs.World, property *ecs.Entity, rentable *components.Rentable, unit *components.Unit, date time.Time) *components.TenantApplication {
+	rent := roundToNearest5(MarketAskingRent(world, property, unit, date))
+	// Most applicants have good credit; rent is between a fifth and a half of their income
+	creditRisk := math.Pow(rand.Float64(), 2)
+	monthlyIncome := roundToNearest5(rent * (2 + 3*rand.Float64()))
+	desiredRent := roundToNearest5(UnitDesiredRent(world, property, unit, date) * (0.9 + 0.2*rand.Float64()))
+
+	leaseMonths := components.StandardLeaseTerms[components.Residential].TermMonths
+	if classifiable, err := property.GetClassifiable(); err == nil {
+		lengths := components.ApplicantLeaseMonths[classifiable.Type]
+		leaseMonths = lengths[rand.Intn(len(lengths))]
+	}
+
+	rentable.ApplicationsReceived++
+	return entities.CreateTenantApplication(rentable.ApplicationsReceived, date, monthlyIncome, rent, desiredRent, leaseMonths, creditRisk)
+}
+
+// ScreenApplication charges the owners the screening fee and reveals the applicant's credit score.
+func ScreenApplication(world *ecs.World, property *ecs.Entity, application *components.TenantApplication, date time.Time) {
+	PostPropertyTransaction(world, property, date, components.ScreeningFees, -components.ScreeningFee,
+		fmt.Sprintf("Screened application %d", application.ID))
+	application.Screened = true
+	application.CreditScore = application.ScreenedCreditScore()
+}
+
+// AcceptApplication moves the applicant into the unit and turns the unit's other applicants away.
+func AcceptApplication(world *ecs.World, property *ecs.Entity, unit *components.Unit, application *components.TenantApplication, date time.Time) *components.Tenant {
+	tenant := moveInTenant(world, property, unit, application, date)
+	fmt.Printf("Tenant moved into property ID %d unit %d after %d days vacant\n", property.ID, unit.Number, countDaysInRange(unit.VacantSince, date)-1)
+	endVacancy(unit)
+	return tenant
+}
+
+// RejectApplication removes the application from the unit's open applications.
+func RejectApplication(unit *components.Unit, application *components.TenantApplication) {
+	open := []*components.TenantApplication{}
+	for _, other := range unit.Applications {
+		if other.ID != application.ID {
+			open = append(open, other)
+		}
+	}
+	unit.Applications = open
+}
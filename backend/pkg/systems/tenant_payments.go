@@ -0,0 +1,203 @@
+package systems
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+	"github.com/markbmullins/city-developer/pkg/entities"
+)
+
+/*
+===========================================================
+
+	Tenant payments
+
+===========================================================
+
+1. **Move-In**
+  - Tenants move into vacant units when their application is accepted (see tenant_applications.go)
+    and sign a lease at the rent they applied at (see leases.go).
+  - The tenant pays a security deposit of one month's rent, held by the owner as a liability.
+  - Commercial tenants open a business in the unit (see business_system.go). Applicants with better credit tend to run better businesses.
+
+2. **Rent Payments**
+  - Each month's rent is charged to the tenant and added to what they already owe.
+  - Most tenants pay everything they owe; some pay only part of it and a few miss the month entirely.
+    Unhappy tenants miss or part-pay more often (see happiness_system.go), as do tenants with poor credit,
+    stretched budgets or who were never screened (see tenant_applications.go) and businesses that are struggling.
+  - Payments settle unpaid rent before late fees.
+
+3. **Late Fees and Arrears**
+  - Rent still unpaid at the end of a month is charged a late fee.
+  - Months that end with rent owed count towards the tenant's months without pay; paying off the rent resets the count.
+  - Tenants who go three months without paying are evicted at the end of the third month and owe nothing after it,
+    even when time moves on several months at once.
+
+4. **Move-Out**
+  - Tenants moving out partway through a month are charged rent for the days they lived there, up to the day before they leave,
+    and pay it as they would any month's rent.
+  - The security deposit is applied to any rent and late fees owed and the rest is refunded to the tenant.
+  - Arrears beyond the deposit are written off.
+  - Tenants move out when they are evicted, when their business closes or when the property is sold, which moves out the tenants of every unit.
+
+===========================================================
+*/
+
+// moveInTenant moves an accepted applicant into the unit on a lease at the rent they applied at
+// and collects their security deposit for the owner.
+func moveInTenant(world *ecs.World, property *ecs.Entity, unit *components.Unit, application *components.TenantApplication, date time.Time) *components.Tenant {
+	classifiable, _ := property.GetClassifiable()
+
+	// The tenant values the unit relative to its base rent as they did when they applied
+	desiredRent := unit.BaseRent
+	if marketDesiredRent := UnitDesiredRent(world, property, unit, date); marketDesiredRent > 0 {
+		desiredRent *= application.DesiredRent / marketDesiredRent
+	}
+
+	tenant := entities.CreateTenant(classifiable.Type, desiredRent, application.Rent, application.LeaseMonths, application.PaymentRisk(), date)
+	if classifiable.Type == components.Commercial {
+		tenant.Business = entities.CreateBusiness(classifiable.Subtype, businessQuality(application), date)
+		tenant.Lease.PercentageRent = tenant.Business.Profile().PercentageRent
+	}
+	unit.Tenant = tenant
+	updateTenantHappiness(world, property, unit, date)
+
+	if tenant.SecurityDeposit > 0 {
+		PostPropertyTransaction(world, property, date, components.SecurityDeposit, tenant.SecurityDeposit, "Security deposit received")
+	}
+	return tenant
+}
+
+// collectRentFromTenant charges the month's rent to the unit's tenant, collects whatever they pay
+// and charges late fees on anything left unpaid. Tenants too far in arrears are evicted.
+// Payments are booked on the last day of the month the rent covers.
+func collectRentFromTenant(world *ecs.World, property *ecs.Entity, unit *components.Unit, rent float64, monthEnd time.Time) {
+	tenant := unit.Tenant
+
+	tenant.RentDue += rent
+	receiveTenantPayment(world, property, tenant, monthEnd)
+
+	if tenant.RentDue <= 0 {
+		tenant.MonthsWithoutPay = 0
+		return
+	}
+
+	tenant.LateFeesDue += tenant.RentDue * components.LateFeePercentage / 100
+	tenant.MonthsWithoutPay++
+	if tenant.MonthsWithoutPay >= components.EvictionMonthsWithoutPay {
+		fmt.Printf("Tenant evicted from property ID %d unit %d owing %.2f\n", property.ID, unit.Number, tenant.Arrears())
+		moveOutTenant(world, property, unit, monthEnd.AddDate(0, 0, 1))
+	}
+}
+
+// receiveTenantPayment collects whatever the tenant pays towards what they owe, settling rent before late fees.
+func receiveTenantPayment(world *ecs.World, property *ecs.Entity, tenant *components.Tenant, date time.Time) {
+	payment := tenantPayment(tenant.Arrears(), happinessRiskMultiplier(tenant)*businessRiskMultiplier(tenant)*tenant.PaymentRisk)
+	rentPaid := math.Min(payment, tenant.RentDue)
+	feesPaid := payment - rentPaid
+	tenant.RentDue -= rentPaid
+	tenant.LateFeesDue -= feesPaid
+
+	if rentPaid > 0 {
+		TransferFunds(world, components.ExternalParty, property.ID, date, components.RentIncome, rentPaid, property.ID, "Rent collected")
+	}
+	if feesPaid > 0 {
+		PostPropertyTransaction(world, property, date, components.LateFeeIncome, feesPaid, "Late fees collected")
+	}
+}
+
+// tenantPayment rolls how much of the amount owed the tenant pays this month.
+// The chances of missing or part-paying are scaled by the risk multiplier.
+func tenantPayment(owed float64, riskMultiplier float64) float64 {
+	if owed <= 0 {
+		return 0
+	}
+	missedChance := components.MissedPaymentChance * riskMultiplier
+	partialChance := components.PartialPaymentChance * riskMultiplier
+	roll := rand.Float64()
+	switch {
+	case roll < missedChance:
+		return 0
+	case roll < missedChance+partialChance:
+		return owed * components.PartialPaymentPercentage / 100
+	default:
+		return owed
+	}
+}
+
+// moveOutTenant charges the tenant's final rent, applies their security deposit to what they owe,
+// refunds the rest from the owners and removes the tenant from the unit on the date they leave.
+func moveOutTenant(world *ecs.World, property *ecs.Entity, unit *components.Unit, date time.Time) {
+	tenant := unit.Tenant
+	if tenant == nil {
+		return
+	}
+
+	chargeFinalRent(world, property, tenant, date)
+
+	// The deposit is already held by the owners, so applying it moves it from the deposit liability into income.
+	rentCovered := math.Min(tenant.SecurityDeposit, tenant.RentDue)
+	feesCovered := math.Min(tenant.SecurityDeposit-rentCovered, tenant.LateFeesDue)
+	if applied := rentCovered + feesCovered; applied > 0 {
+		PostPropertyTransaction(world, property, date, components.DepositRefund, -applied, "Security deposit applied to arrears")
+		if rentCovered > 0 {
+			PostPropertyTransaction(world, property, date, components.RentIncome, rentCovered, "Rent covered by security deposit")
+		}
+		if feesCovered > 0 {
+			PostPropertyTransaction(world, property, date, components.LateFeeIncome, feesCovered, "Late fees covered by security deposit")
+		}
+	}
+
+	if refund := tenant.SecurityDeposit - rentCovered - feesCovered; refund > 0 {
+		PostPropertyTransaction(world, property, date, components.DepositRefund, -refund, "Security deposit refunded")
+	}
+
+	unit.Tenant = nil
+	startVacancy(unit, date)
+}
+
+// chargeFinalRent charges the tenant for the days of the month they lived in the unit before moving out,
+// along with any percentage rent not yet charged. Earlier months have already been charged by rent collection,
+// which runs before tenants move out, as has the month they leave in if time has since moved past it.
+func chargeFinalRent(world *ecs.World, property *ecs.Entity, tenant *components.Tenant, date time.Time) {
+	rent := 0.0
+	gameTime, _ := world.GetCurrentGameTime()
+	if !monthStart(date).Before(monthStart(gameTime.LastUpdated)) {
+		rent = leaseRent(property, tenant, monthStart(date), date.AddDate(0, 0, -1))
+	}
+	if business := tenant.Business; business != nil {
+		rent += business.PercentageRentDue
+		business.PercentageRentDue = 0
+	}
+	if rent <= 0 {
+		return
+	}
+	tenant.RentDue += rent
+	receiveTenantPayment(world, property, tenant, date)
+}
+
+// securityDepositsHeld returns the player's share of the security deposits held for tenants in their properties.
+func securityDepositsHeld(world *ecs.World, playerID int) float64 {
+	total := 0.0
+	for _, property := range PropertiesHeldBy(world, playerID) {
+		total += propertySecurityDeposits(property) * ownershipFraction(property, playerID)
+	}
+	return total
+}
+
+// propertySecurityDeposits is the total security deposit held for the tenants of every unit of the property.
+func propertySecurityDeposits(property *ecs.Entity) float64 {
+	total := 0.0
+	if rentable, err := property.GetRentable(); err == nil {
+		for _, unit := range rentable.Units {
+			if unit.Tenant != nil {
+				total += unit.Tenant.SecurityDeposit
+			}
+		}
+	}
+	return total
+}
@@ -0,0 +1,106 @@
+package systems
+
+import (
+	"testing"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/entities"
+)
+
+func TestMoveOutTenant(t *testing.T) {
+	tests := []struct {
+		name              string
+		lastUpdated       time.Time
+		moveOut           time.Time
+		percentageRentDue float64
+		wantRent          float64
+		wantRefund        float64
+	}{
+		{
+			name:        "charged up to the day before they leave",
+			lastUpdated: date(2024, time.March, 20),
+			moveOut:     date(2024, time.March, 10),
+			wantRent:    900, // 9 of 31 days
+			wantRefund:  -3100,
+		},
+		{
+			name:        "leaving on the first owes nothing more",
+			lastUpdated: date(2024, time.March, 1),
+			moveOut:     date(2024, time.March, 1),
+			wantRent:    0,
+			wantRefund:  -3100,
+		},
+		{
+			name:        "month already charged by rent collection",
+			lastUpdated: date(2024, time.April, 1),
+			moveOut:     date(2024, time.March, 15),
+			wantRent:    0,
+			wantRefund:  -3100,
+		},
+		{
+			name:              "percentage rent not yet charged",
+			lastUpdated:       date(2024, time.March, 20),
+			moveOut:           date(2024, time.March, 10),
+			percentageRentDue: 500,
+			wantRent:          1400,
+			wantRefund:        -3100,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			world := newTestWorld(test.lastUpdated)
+			owner := addTestPlayer(world, 0)
+			property := addTestProperty(world, owner, 300000, 3100, date(2023, time.December, 1))
+			tenant := entities.CreateTenant(components.Residential, 3100, 3100, 12, 0, date(2024, time.January, 1))
+			tenant.Business = &components.Business{PercentageRentDue: test.percentageRentDue}
+			rentable, _ := property.GetRentable()
+			unit := rentable.Units[0]
+			unit.Tenant = tenant
+
+			moveOutTenant(world, property, unit, test.moveOut)
+
+			if unit.Tenant != nil {
+				t.Fatalf("tenant still in the unit")
+			}
+			if !unit.VacantSince.Equal(test.moveOut) {
+				t.Errorf("vacant since %v, want %v", unit.VacantSince, test.moveOut)
+			}
+			if got := ledgerTotal(owner, components.RentIncome, property.ID); got != test.wantRent {
+				t.Errorf("rent income = %.2f, want %.2f", got, test.wantRent)
+			}
+			if got := ledgerTotal(owner, components.DepositRefund, property.ID); got != test.wantRefund {
+				t.Errorf("deposit refunded = %.2f, want %.2f", got, test.wantRefund)
+			}
+		})
+	}
+}
+
+func TestEvictionDuringCatchUp(t *testing.T) {
+	// Time moves on five months at once for a tenant who never pays
+	world := newTestWorld(date(2024, time.June, 1))
+	owner := addTestPlayer(world, 0)
+	property := addTestProperty(world, owner, 300000, 3100, date(2023, time.December, 1))
+	tenant := entities.CreateTenant(components.Residential, 3100, 3100, 12, 100, date(2024, time.January, 1))
+	tenant.Happiness.Value = 0
+	rentable, _ := property.GetRentable()
+	unit := rentable.Units[0]
+	unit.Tenant = tenant
+
+	processRent(world, date(2024, time.January, 1), 5)
+
+	if unit.Tenant != nil {
+		t.Fatalf("tenant not evicted")
+	}
+	if want := date(2024, time.April, 1); !unit.VacantSince.Equal(want) {
+		t.Errorf("evicted on %v, want %v", unit.VacantSince, want)
+	}
+	// Three months of rent are charged and nothing after the eviction; only the deposit is collected
+	if tenant.RentDue != 9300 {
+		t.Errorf("rent charged = %.2f, want 9300", tenant.RentDue)
+	}
+	if got := ledgerTotal(owner, components.RentIncome, property.ID); got != 3100 {
+		t.Errorf("rent income = %.2f, want 3100", got)
+	}
+}
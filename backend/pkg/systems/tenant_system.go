@@ -0,0 +1,134 @@
+package systems
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/*
+===========================================================
+
+	Tenant system
+
+===========================================================
+
+1. **Units and Vacancy**
+  - Every rentable property is let by the unit: houses are a single unit, while apartment buildings,
+    multifamily homes and condo blocks have several, each with its own tenant, lease and asking rent.
+  - An owned unit without a tenant is vacant. Newly bought properties start out with every unit vacant,
+    and a unit becomes vacant again whenever its tenant moves out.
+  - A vacant unit spends a week being turned over before it is listed on the rental market.
+
+2. **Arrivals**
+  - Listed units receive applications from prospective tenants at a rate set by their occupancy probability
+    at the asking rent (the unit's standard rent if the owner hasn't set one), so a unit at the market rent
+    usually lets within a month. Higher rents and a weaker economy make units take longer to let.
+  - The owners screen, accept or reject applicants, and the property manager accepts applications left
+    unanswered for a week (see tenant_applications.go).
+  - The tenant moves in on the day they are accepted and pays their security deposit (see tenant_payments.go).
+
+3. **Move-Outs**
+  - At the start of every month, once the rent for the month just ended has been charged, each tenant whose lease allows it
+    breaks their lease with their move-out chance, which depends on their happiness (see leases.go and happiness_system.go).
+  - Tenants also move out when they don't renew their lease, are evicted for arrears or the property is sold.
+  - Commercial tenants whose business has closed move out after their final month's rent is charged (see business_system.go).
+
+4. **Income**
+  - Rent is only collected for the days each unit is occupied, and the property's rent is the sum over its units
+    (see rent_collection_system.go), while operating expenses are charged for every day it is owned.
+
+===========================================================
+*/
+type TenantSystem struct{}
+
+const (
+	vacancyTurnoverDays = 7
+	averageDaysToLet    = 21.0
+)
+
+func (s *TenantSystem) Update(world *ecs.World) {
+	gameTime, _ := world.GetCurrentGameTime()
+	if gameTime.IsPaused {
+		return
+	}
+	now := gameTime.CurrentDate
+
+	for _, property := range world.GetAllProperties() {
+		rentable, err := property.GetRentable()
+		if err != nil {
+			continue
+		}
+		ownable, _ := property.GetOwnable()
+		if ownable == nil || !ownable.Owned {
+			for _, unit := range rentable.Units {
+				endVacancy(unit)
+			}
+			continue
+		}
+
+		for _, unit := range rentable.Units {
+			if tenant := unit.Tenant; tenant != nil {
+				if tenant.Business != nil && tenant.Business.Closed {
+					moveOutTenant(world, property, unit, tenant.Business.LastUpdated)
+					continue
+				}
+				if tenant.Lease != nil && !now.Before(tenant.Lease.EndDate) && !renewLease(world, property, unit, now) {
+					continue
+				}
+				rollMoveOut(world, property, unit, now)
+				continue
+			}
+
+			if unit.VacantSince.IsZero() {
+				startVacancy(unit, now)
+			}
+			receiveApplications(world, property, rentable, unit, now)
+		}
+	}
+}
+
+// MarketAskingRent returns the rent a vacant unit is advertised at: the owner's asking rent if set, or the unit's standard rent.
+func MarketAskingRent(world *ecs.World, property *ecs.Entity, unit *components.Unit, date time.Time) float64 {
+	if unit.AskingRent > 0 {
+		return unit.AskingRent
+	}
+	return UnitStandardRent(world, property, unit, date)
+}
+
+// expectedOccupancy is the share of time the unit is expected to have a tenant: 1 while it is occupied,
+// otherwise the chance a prospective tenant takes it at the advertised rent.
+func expectedOccupancy(world *ecs.World, property *ecs.Entity, unit *components.Unit, date time.Time) float64 {
+	if unit.Tenant != nil {
+		return 1
+	}
+	return OccupancyProbability(world, property, unit, MarketAskingRent(world, property, unit, date), date)
+}
+
+// rollMoveOut decides at the start of each month whether the unit's tenant breaks their lease and moves out.
+func rollMoveOut(world *ecs.World, property *ecs.Entity, unit *components.Unit, now time.Time) {
+	tenant := unit.Tenant
+	for !nextMonthStart(tenant.LastMoveOutRoll).After(now) {
+		tenant.LastMoveOutRoll = nextMonthStart(tenant.LastMoveOutRoll)
+		if rand.Float64() < tenant.MoveOutChance {
+			breakLease(world, property, unit, tenant.LastMoveOutRoll)
+			return
+		}
+	}
+}
+
+func startVacancy(unit *components.Unit, date time.Time) {
+	unit.VacantSince = date
+	unit.ListedDate = date.AddDate(0, 0, vacancyTurnoverDays)
+	unit.LastShowingDate = time.Time{}
+	unit.Applications = nil
+}
+
+func endVacancy(unit *components.Unit) {
+	unit.VacantSince = time.Time{}
+	unit.ListedDate = time.Time{}
+	unit.LastShowingDate = time.Time{}
+	unit.Applications = nil
+}
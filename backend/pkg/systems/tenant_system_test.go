@@ -0,0 +1,55 @@
+package systems
+
+import (
+	"testing"
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/entities"
+)
+
+func TestClosedBusinessMovesOut(t *testing.T) {
+	tests := []struct {
+		name string
+		now  time.Time
+	}{
+		{name: "on the day after the closing month", now: date(2024, time.April, 1)},
+		{name: "after time has moved on past the closure", now: date(2024, time.June, 10)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			world := newTestWorld(test.now)
+			owner := addTestPlayer(world, 0)
+			property := addTestProperty(world, owner, 300000, 3100, date(2023, time.December, 1))
+			tenant := entities.CreateTenant(components.Commercial, 3100, 3100, 60, 0, date(2024, time.January, 1))
+			tenant.Business = entities.CreateBusiness(components.Bookstore, 1, date(2024, time.January, 1))
+			// The business closed at the end of March, owing percentage rent on its March sales
+			tenant.Business.LastUpdated = date(2024, time.April, 1)
+			tenant.Business.PercentageRentDue = 500
+			tenant.Business.Closed = true
+			rentable, _ := property.GetRentable()
+			unit := rentable.Units[0]
+			unit.Tenant = tenant
+			gameTime, _ := world.GetCurrentGameTime()
+			gameTime.LastUpdated = date(2024, time.March, 15)
+
+			(&RentCollectionSystem{}).Update(world)
+			(&TenantSystem{}).Update(world)
+
+			if unit.Tenant != nil {
+				t.Fatalf("tenant still in the unit")
+			}
+			if want := date(2024, time.April, 1); !unit.VacantSince.Equal(want) {
+				t.Errorf("moved out on %v, want %v", unit.VacantSince, want)
+			}
+			// March's base and percentage rent, and nothing for the months after
+			if got := ledgerTotal(owner, components.RentIncome, property.ID); got != 3600 {
+				t.Errorf("rent income = %.2f, want 3600", got)
+			}
+			if got := ledgerTotal(owner, components.DepositRefund, property.ID); got != -3100 {
+				t.Errorf("deposit refunded = %.2f, want -3100", got)
+			}
+		})
+	}
+}
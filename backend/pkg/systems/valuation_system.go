@@ -0,0 +1,186 @@
+package systems
+
+import (
+	"time"
+
+	"github.com/markbmullins/city-developer/pkg/components"
+	"github.com/markbmullins/city-developer/pkg/ecs"
+)
+
+/*
+===========================================================
+
+	Valuation system
+
+===========================================================
+
+Every property is revalued once a month. The market value is the base (listed) value
+multiplied by the following factors:
+  - **Income:** Net rent including completed upgrades, relative to the net base rent.
+    Upgrades therefore raise resale value in proportion to the rent they add.
+  - **Condition:** From 1.0 in perfect condition down to 0.7 for a property in ruins.
+  - **Neighborhood:** Up to +20% as the share of upgraded properties in the group grows.
+  - **Market:** Demand in the neighborhood, from -5% when none of the other properties are owned to +5% when all are.
+  - **Price Index:** The economy's property price index (see the economy system).
+  - **Location:** The neighborhood's own appreciation (see the market index system).
+  - **Inflation:** The cumulative inflation index (see the inflation system).
+
+Properties are bought at their market value and sold at their market value less selling costs.
+
+===========================================================
+*/
+type ValuationSystem struct{}
+
+func (s *ValuationSystem) Update(world *ecs.World) {
+	gameTime, _ := world.GetCurrentGameTime()
+	if gameTime.IsPaused {
+		return
+	}
+
+	for _, property := range world.GetAllProperties() {
+		valuation, err := property.GetValuation()
+		if err != nil {
+			continue
+		}
+		if !valuation.LastValuationDate.IsZero() && nextMonthStart(valuation.LastValuationDate).After(gameTime.CurrentDate) {
+			continue
+		}
+		ValueProperty(world, property, gameTime.CurrentDate)
+	}
+}
+
+// ValueProperty recomputes the market value of a property as of the given date.
+func ValueProperty(world *ecs.World, property *ecs.Entity, date time.Time) {
+	valuation, err := property.GetValuation()
+	if err != nil {
+		return
+	}
+
+	valuation.Factors = map[string]float64{
+		"Income":       incomeValueFactor(property, date),
+		"Condition":    conditionValueFactor(property),
+		"Neighborhood": neighborhoodValueFactor(world, property, date),
+		"Market":       marketValueFactor(world, property),
+		"PriceIndex":   priceIndexValueFactor(world),
+		"Location":     locationValueFactor(world, property),
+		"Inflation":    InflationIndex(world),
+	}
+
+	value := valuation.BaseValue
+	for _, factor := range valuation.Factors {
+		value *= factor
+	}
+	valuation.MarketValue = value
+	valuation.LastValuationDate = date
+}
+
+// PropertyValue returns the current market value of a property, falling back to its purchase cost.
+func PropertyValue(property *ecs.Entity) float64 {
+	if valuation, err := property.GetValuation(); err == nil {
+		return valuation.MarketValue
+	}
+	purchaseable, _ := property.GetPurchaseable()
+	return purchaseable.Cost
+}
+
+// SalePrice returns what the owner receives when selling a property at market value.
+func SalePrice(property *ecs.Entity) float64 {
+	return PropertyValue(property) * (1 - components.SellingCostPercentage/100)
+}
+
+func incomeValueFactor(property *ecs.Entity, date time.Time) float64 {
+	rentable, err := property.GetRentable()
+	if err != nil {
+		return 1
+	}
+
+	baseNetRent := netMonthlyRent(property, rentable.BaseRent)
+	if baseNetRent <= 0 {
+		return 1
+	}
+	return netMonthlyRent(property, rentable.BaseRent+completedUpgradeRent(property, date)) / baseNetRent
+}
+
+// netMonthlyRent is the gross rent less the operating expenses that do not depend on the property value.
+func netMonthlyRent(property *ecs.Entity, grossRent float64) float64 {
+	operatingExpenses, err := property.GetOperatingExpenses()
+	if err != nil {
+		return grossRent
+	}
+	rates := operatingExpenses.Rates
+	return grossRent*(1-(rates.ManagementFeePercentage+rates.VacancyReservePercentage)/100) - monthlyUtilities(property, rates)
+}
+
+// completedUpgradeRent sums the rent increases of applied upgrades completed by the given date.
+func completedUpgradeRent(property *ecs.Entity, date time.Time) float64 {
+	upgradable, err := property.GetUpgradable()
+	if err != nil {
+		return 0
+	}
+	total := 0.0
+	for _, upgrade := range upgradable.AppliedUpgrades {
+		if isUpgradeComplete(upgrade, date) {
+			total += upgrade.RentIncrease
+		}
+	}
+	return total
+}
+
+func isUpgradeComplete(upgrade *components.Upgrade, date time.Time) bool {
+	return upgrade.PurchaseDate.AddDate(0, 0, upgrade.DaysToComplete).Before(date)
+}
+
+func conditionValueFactor(property *ecs.Entity) float64 {
+	maintainable, err := property.GetMaintainable()
+	if err != nil {
+		return 1
+	}
+	return 0.7 + 0.3*maintainable.Condition/100
+}
+
+func neighborhoodValueFactor(world *ecs.World, property *ecs.Entity, date time.Time) float64 {
+	groupable, _ := property.GetGroupable()
+	groupProperties := propertiesInGroup(world, groupable.GroupID)
+	if len(groupProperties) == 0 {
+		return 1
+	}
+
+	upgraded := 0
+	for _, groupProperty := range groupProperties {
+		if completedUpgradeRent(groupProperty, date) > 0 {
+			upgraded++
+		}
+	}
+	return 1 + 0.2*float64(upgraded)/float64(len(groupProperties))
+}
+
+// marketValueFactor measures demand from the share of the other properties in the neighborhood that are owned,
+// so buying a property doesn't mark up its own value.
+func marketValueFactor(world *ecs.World, property *ecs.Entity) float64 {
+	groupable, _ := property.GetGroupable()
+	others, owned := 0, 0
+	for _, groupProperty := range propertiesInGroup(world, groupable.GroupID) {
+		if groupProperty.ID == property.ID {
+			continue
+		}
+		others++
+		if ownable, _ := groupProperty.GetOwnable(); ownable.Owned {
+			owned++
+		}
+	}
+	if others == 0 {
+		return 1
+	}
+	return 0.95 + 0.1*float64(owned)/float64(others)
+}
+
+// propertiesInGroup returns every property in the group.
+func propertiesInGroup(world *ecs.World, groupID int) []*ecs.Entity {
+	properties := []*ecs.Entity{}
+	for _, entity := range world.GetEntitiesInGroup(groupID) {
+		if entity != nil && entity.Type == "Property" {
+			properties = append(properties, entity)
+		}
+	}
+	return properties
+}